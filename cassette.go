@@ -0,0 +1,351 @@
+package go_tenable
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Recording and replaying API traffic
+
+// CassetteMode selects whether a Recorder talks to the network or serves previously recorded responses.
+type CassetteMode int
+
+const (
+	// ModeReplay serves every request from the cassette file and never touches the network.
+	ModeReplay CassetteMode = iota
+	// ModeRecord sends requests to the real API and keeps a sanitized copy of each interaction until Save is called.
+	ModeRecord
+)
+
+// ErrInteractionNotFound is returned in ModeReplay when no unused interaction in the cassette matches a request.
+var ErrInteractionNotFound = errors.New("no recorded interaction matches the request")
+
+// Cassette is the on-disk form of a recording: every request and response in the order they completed.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a sanitized request. URL holds only the path and query so the tenant's host name is not kept.
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records API interactions to a cassette file and replays them later. Pass it
+// to a client with WithRoundTripper:
+//
+//	rec, err := go_tenable.NewRecorder("testdata/sc-assets.json", go_tenable.ModeRecord, nil)
+//	sc, err := go_tenable.NewTenableSC("sc.example.com", go_tenable.WithRoundTripper(rec))
+//	...
+//	err = rec.Save()
+//
+// Interactions are sanitized before they are kept: credential headers are masked, the value of every session cookie
+// is replaced, sensitive JSON fields such as tokens, passwords and keys are masked (numbers become 0 so the body
+// still decodes), and IPv4 and IPv6 addresses are replaced with addresses from the 198.18.0.0/15 and 2001:db8::/32
+// documentation ranges. Addresses already in those ranges are left alone. A replacement is derived from a hash of the
+// address alone, so an address maps to the same replacement in every recording and during replay, and a request that
+// carries a real address still matches its interaction.
+//
+// In ModeReplay each request is matched on its method, sanitized URL and sanitized body against the first unused
+// interaction, so repeated requests such as export status polls receive the recorded responses in order.
+type Recorder struct {
+	mode      CassetteMode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder creates a Recorder for the cassette at path. In ModeRecord requests are sent through transport, or
+// http.DefaultTransport when it is nil. In ModeReplay the cassette is loaded immediately and transport is ignored.
+func NewRecorder(path string, mode CassetteMode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+	}
+	switch mode {
+	case ModeRecord:
+		if r.transport == nil {
+			r.transport = http.DefaultTransport
+		}
+	case ModeReplay:
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+		r.used = make([]bool, len(cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %d", mode)
+	}
+	return r, nil
+}
+
+// LoadCassette reads a cassette file written by Recorder.Save.
+func LoadCassette(path string) (Cassette, error) {
+	var cassette Cassette
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cassette, err
+	}
+	if err = json.Unmarshal(data, &cassette); err != nil {
+		return cassette, fmt.Errorf("unable to parse cassette %v: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// Save writes the interactions recorded so far to the cassette file. It does nothing in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0600)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	outgoing := req.Clone(req.Context())
+	if body != nil {
+		outgoing.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: CassetteRequest{
+			Method:  req.Method,
+			URL:     r.scrubText(req.URL.RequestURI()),
+			Headers: r.scrubHeaders(req.Header),
+			Body:    r.scrubBody(body),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubHeaders(resp.Header),
+			Body:       r.scrubBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	uri := r.scrubText(req.URL.RequestURI())
+	scrubbed := r.scrubBody(body)
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != uri ||
+			interaction.Request.Body != scrubbed {
+			continue
+		}
+		r.used[i] = true
+
+		header := http.Header{}
+		for key, values := range interaction.Response.Headers {
+			header[key] = append([]string(nil), values...)
+		}
+		header.Del("Content-Length")
+		header.Del("Content-Encoding")
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %v", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %v %v", ErrInteractionNotFound, req.Method, uri)
+}
+
+// Sanitizing
+
+// scrubHeaders masks credential headers, keeps cookie names but replaces their values so Tenable.sc logins still
+// find the TNS_SESSIONID cookie on replay, and replaces IP addresses in everything else.
+func (r *Recorder) scrubHeaders(header http.Header) http.Header {
+	ret := make(http.Header, len(header))
+	for key, values := range header {
+		canonical := http.CanonicalHeaderKey(key)
+		for _, value := range values {
+			switch {
+			case canonical == "Set-Cookie":
+				ret.Add(key, scrubSetCookie(value))
+			case isSensitiveHeader(canonical):
+				ret.Add(key, redacted)
+			default:
+				ret.Add(key, r.scrubText(value))
+			}
+		}
+	}
+	return ret
+}
+
+func scrubSetCookie(value string) string {
+	parts := strings.SplitN(value, ";", 2)
+	name := strings.SplitN(parts[0], "=", 2)[0]
+	ret := name + "=" + redacted
+	if len(parts) == 2 {
+		ret += ";" + parts[1]
+	}
+	return ret
+}
+
+// scrubBody masks sensitive fields in JSON bodies and replaces IP addresses in any body.
+func (r *Recorder) scrubBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return r.scrubText(string(body))
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r.scrubValue(decoded, false)); err != nil {
+		return r.scrubText(string(body))
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// scrubValue walks a decoded JSON value. Every string and number under a sensitive field is masked, keeping its
+// type so the body still decodes into the library's structs.
+func (r *Recorder) scrubValue(value interface{}, sensitive bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = r.scrubValue(child, sensitive || isSensitiveField(key))
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = r.scrubValue(child, sensitive)
+		}
+	case string:
+		if sensitive {
+			return redacted
+		}
+		return r.scrubText(v)
+	case json.Number:
+		if sensitive {
+			return json.Number("0")
+		}
+	}
+	return value
+}
+
+var (
+	ipv4Pattern = regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`)
+	ipv6Pattern = regexp.MustCompile(`[0-9A-Fa-f]{0,4}(?::[0-9A-Fa-f]{0,4}){2,7}`)
+
+	_, fakeIPv4Net, _ = net.ParseCIDR("198.18.0.0/15")
+	_, fakeIPv6Net, _ = net.ParseCIDR("2001:db8::/32")
+)
+
+// scrubText replaces every IP address in s. The same address always maps to the same replacement.
+func (r *Recorder) scrubText(s string) string {
+	s = ipv4Pattern.ReplaceAllStringFunc(s, replaceIP)
+
+	// ipv6Pattern also matches the "::" in text such as "std::string", so a candidate is only an address when it
+	// contains a hex digit and is not joined to a letter, digit or colon on either side.
+	var b strings.Builder
+	last := 0
+	for _, loc := range ipv6Pattern.FindAllStringIndex(s, -1) {
+		start, end := loc[0], loc[1]
+		if (start > 0 && isIPv6Neighbour(s[start-1])) || (end < len(s) && isIPv6Neighbour(s[end])) ||
+			!strings.ContainsAny(s[start:end], "0123456789abcdefABCDEF") {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(replaceIP(s[start:end]))
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// isIPv6Neighbour reports whether c would continue the text around an IPv6 address candidate.
+func isIPv6Neighbour(c byte) bool {
+	return c == ':' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// replaceIP maps an address to one in the documentation ranges taken from a SHA-256 hash of it. IPv4 addresses keep
+// 17 bits of the hash, so each replacement stands for many real addresses and cannot be reversed by hashing every
+// IPv4 address; two addresses in one recording may share a replacement.
+func replaceIP(candidate string) string {
+	ip := net.ParseIP(candidate)
+	if ip == nil || fakeIPv4Net.Contains(ip) || fakeIPv6Net.Contains(ip) {
+		return candidate
+	}
+
+	sum := sha256.Sum256(append([]byte("go-tenable cassette\x00"), ip.To16()...))
+	if ip.To4() != nil {
+		return net.IPv4(198, 18+sum[0]&1, sum[1], sum[2]).String()
+	}
+	replacement := make(net.IP, net.IPv6len)
+	copy(replacement, fakeIPv6Net.IP[:4])
+	copy(replacement[4:], sum[:12])
+	return replacement.String()
+}
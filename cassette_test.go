@@ -0,0 +1,94 @@
+package go_tenable_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thathaneydude/go-tenable"
+)
+
+// pluginOutput holds text that looks like IPv6 addresses to a loose pattern, and one real address.
+const pluginOutput = "std::string ACE::Foo [System.Net.IPAddress]::Parse a :: b listening on fe80::1, (2001:4860::8888)"
+
+// TestRecorderReplaysRequestsCarryingIPs records a response that contains an address before a request that carries
+// another one, then replays the requests from the saved cassette.
+func TestRecorderReplaysRequestsCarryingIPs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/session":
+			fmt.Fprint(w, `{"lastLoginIP": "10.1.2.3"}`)
+		case "/plugin":
+			fmt.Fprintf(w, `{"output": %q}`, pluginOutput)
+		case "/hosts":
+			fmt.Fprintf(w, `{"ip": %q}`, r.URL.Query().Get("ip"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+	run := func(mode go_tenable.CassetteMode) []string {
+		rec, err := go_tenable.NewRecorder(path, mode, srv.Client().Transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tio, err := go_tenable.NewTenableIO("access", "secret", go_tenable.WithBaseURL(srv.URL),
+			go_tenable.WithRoundTripper(rec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var bodies []string
+		for _, req := range []struct{ endpoint, params string }{
+			{"session", ""},
+			{"plugin", ""},
+			{"hosts", "ip=10.9.9.9"},
+			{"hosts", "ip=2001:4860:4860::8888"},
+		} {
+			resp, err := tio.Get(req.endpoint, req.params)
+			if err != nil {
+				t.Fatalf("%v %v: %v", req.endpoint, req.params, err)
+			}
+			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			bodies = append(bodies, string(body))
+		}
+		if err = rec.Save(); err != nil {
+			t.Fatal(err)
+		}
+		return bodies
+	}
+
+	run(go_tenable.ModeRecord)
+	replayed := run(go_tenable.ModeReplay)
+	if !strings.Contains(replayed[2], `"198.1`) || !strings.Contains(replayed[3], `"2001:db8:`) {
+		t.Errorf("replayed bodies %q do not carry the replacement addresses", replayed)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ip := range []string{"10.1.2.3", "10.9.9.9", "2001:4860:4860::8888", "fe80::1", "2001:4860::8888"} {
+		if strings.Contains(string(data), ip) {
+			t.Errorf("cassette contains %v", ip)
+		}
+	}
+	for _, text := range []string{"std::string", "ACE::Foo", "[System.Net.IPAddress]::Parse", "a :: b"} {
+		if !strings.Contains(replayed[1], text) {
+			t.Errorf("replayed plugin output %q lost %q", replayed[1], text)
+		}
+	}
+}
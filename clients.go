@@ -2,69 +2,89 @@ package go_tenable
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"io/ioutil"
 	"net/http"
-	"strings"
+	"sync"
+	"time"
 )
 
-var restrictedEndpoints = []string{"token"}
-
 // Creating a New Clients
-func NewTenableIOClient(accessKey string, secretKey string, transport *http.Transport) TenableIO {
-	headers := &http.Header{}
-	headers.Set("X-ApiKeys", fmt.Sprintf("accessKey=%v; secretKey=%v;", accessKey, secretKey))
-	headers.Set("Content-Type", "application/json")
-	headers.Set("User-Agent", "GoTenable")
-
-	b := &baseClient{
-		&http.Client{},
-		headers}
 
-	if transport != nil {
-		b.HttpClient.Transport = transport
+// NewTenableIO creates a Tenable.io client authenticated with the provided API keys. Requests go to
+// https://cloud.tenable.com unless WithBaseURL is supplied.
+func NewTenableIO(accessKey string, secretKey string, opts ...Option) (TenableIO, error) {
+	o := buildOptions(opts)
+	baseURL, err := o.resolveBaseURL("https://cloud.tenable.com")
+	if err != nil {
+		return TenableIO{}, err
+	}
+	b, err := newBaseClient(o, ProductIO)
+	if err != nil {
+		return TenableIO{}, err
 	}
+	b.Headers.Set("X-ApiKeys", fmt.Sprintf("accessKey=%v; secretKey=%v;", accessKey, secretKey))
+
 	client := TenableIO{
 		*b,
 		accessKey,
 		secretKey,
-		"https://cloud.tenable.com"}
-	return client
+		baseURL,
+		o.scannerID}
+	return client, nil
 }
 
-func NewTenableSCClient(scHost string, transport *http.Transport) TenableSC {
-	headers := &http.Header{}
-	headers.Set("Content-Type", "application/json")
-	headers.Set("User-Agent", "GoTenable")
-
-	b := &baseClient{
-		&http.Client{},
-		headers}
-
-	if transport != nil {
-		b.HttpClient.Transport = transport
+// NewTenableSC creates a Tenable.sc client for the console at scHost. Requests go to https://<scHost>/rest unless
+// WithBaseURL is supplied, in which case scHost may be left empty. Login must be called before any other request.
+func NewTenableSC(scHost string, opts ...Option) (TenableSC, error) {
+	o := buildOptions(opts)
+	baseURL, err := o.resolveBaseURL(fmt.Sprintf("https://%v/rest", scHost))
+	if err != nil {
+		return TenableSC{}, err
+	}
+	b, err := newBaseClient(o, ProductSC)
+	if err != nil {
+		return TenableSC{}, err
 	}
 
 	sc := TenableSC{
 		BaseClient: *b,
-		BaseURL:    fmt.Sprintf("https://%v/rest", scHost),
+		BaseURL:    baseURL,
+		renewal:    &sessionRenewal{enabled: o.sessionRenewal, onReauth: o.onReauth},
 	}
-	return sc
+	return sc, nil
 }
 
-func NewNessusClient(accessKey string, secretKey string, nessusAddress string, port int, transport *http.Transport) Nessus {
-	headers := &http.Header{}
-	headers.Set("X-ApiKeys", fmt.Sprintf("accessKey=%v; secretKey=%v;", accessKey, secretKey))
-	headers.Set("Content-Type", "application/json")
-	headers.Set("User-Agent", "GoTenable")
-
-	b := &baseClient{
-		&http.Client{},
-		headers}
+// NewTenableSCWithAPIKeys creates a Tenable.sc client that authenticates every request with an API access and secret
+// key (Tenable.sc 5.13+). No session is created, so Login and Logout are not needed.
+func NewTenableSCWithAPIKeys(scHost string, accessKey string, secretKey string, opts ...Option) (TenableSC, error) {
+	sc, err := NewTenableSC(scHost, opts...)
+	if err != nil {
+		return sc, err
+	}
+	sc.accessKey = accessKey
+	sc.secretKey = secretKey
+	sc.BaseClient.Headers.Set("X-APIKey", fmt.Sprintf("accesskey=%v; secretkey=%v;", accessKey, secretKey))
+	return sc, nil
+}
 
-	if transport != nil {
-		b.HttpClient.Transport = transport
+// NewNessus creates a Nessus client authenticated with the provided API keys. Requests go to
+// https://<nessusAddress>:<port> unless WithBaseURL is supplied.
+func NewNessus(accessKey string, secretKey string, nessusAddress string, port int, opts ...Option) (Nessus, error) {
+	o := buildOptions(opts)
+	baseURL, err := o.resolveBaseURL(fmt.Sprintf("https://%v:%v", nessusAddress, port))
+	if err != nil {
+		return Nessus{}, err
+	}
+	b, err := newBaseClient(o, ProductNessus)
+	if err != nil {
+		return Nessus{}, err
 	}
+	b.Headers.Set("X-ApiKeys", fmt.Sprintf("accessKey=%v; secretKey=%v;", accessKey, secretKey))
 
 	nessus := Nessus{
 		BaseClient: *b,
@@ -72,126 +92,248 @@ func NewNessusClient(accessKey string, secretKey string, nessusAddress string, p
 		secretKey:  secretKey,
 		Address:    nessusAddress,
 		Port:       port,
-		BaseURL:    fmt.Sprintf("https://%v:%v", nessusAddress, port),
+		BaseURL:    baseURL,
 	}
+	return nessus, nil
+}
+
+// NewTenableIOClient is equivalent to NewTenableIO with WithRoundTripper(transport) and discards configuration
+// errors.
+func NewTenableIOClient(accessKey string, secretKey string, transport *http.Transport) TenableIO {
+	client, _ := NewTenableIO(accessKey, secretKey, transportOption(transport))
+	return client
+}
+
+// NewTenableSCClient is equivalent to NewTenableSC with WithRoundTripper(transport) and discards configuration
+// errors.
+func NewTenableSCClient(scHost string, transport *http.Transport) TenableSC {
+	sc, _ := NewTenableSC(scHost, transportOption(transport))
+	return sc
+}
+
+// NewNessusClient is equivalent to NewNessus with WithRoundTripper(transport) and discards configuration errors.
+func NewNessusClient(accessKey string, secretKey string, nessusAddress string, port int, transport *http.Transport) Nessus {
+	nessus, _ := NewNessus(accessKey, secretKey, nessusAddress, port, transportOption(transport))
 	return nessus
 }
 
+// transportOption adapts the *http.Transport accepted by the original constructors, leaving the default transport in
+// place when it is nil.
+func transportOption(transport *http.Transport) Option {
+	if transport == nil {
+		return nil
+	}
+	return WithRoundTripper(transport)
+}
+
 // Base Client Functions
 
 func (bc baseClient) Get(baseURL string, endpoint string, params string) (*http.Response, error) {
+	return bc.GetWithContext(context.Background(), baseURL, endpoint, params)
+}
+
+// GetWithContext issues a GET request that is bound to ctx, allowing callers to cancel it or apply a deadline.
+func (bc baseClient) GetWithContext(ctx context.Context, baseURL string, endpoint string, params string) (*http.Response, error) {
 	var fullURL string
 	if params != "" {
 		fullURL = fmt.Sprintf("%v/%v?%v", baseURL, endpoint, params)
 	} else {
 		fullURL = fmt.Sprintf("%v/%v", baseURL, endpoint)
 	}
-	log.Printf("Requesting GET --> %v\n", fullURL)
-	req, err := http.NewRequest("GET", fullURL, nil)
-	if err != nil {
-		log.Printf("Unable to build GET request \"%v\": %v\n", fullURL, err)
-		return nil, err
-	}
+	return bc.do(ctx, "GET", endpoint, fullURL, nil)
+}
 
-	req.Header = *bc.Headers
+func (bc baseClient) Post(baseURL string, endpoint string, body []byte) (*http.Response, error) {
+	return bc.PostWithContext(context.Background(), baseURL, endpoint, body)
+}
 
-	httpResp, err := bc.HttpClient.Do(req)
-	if err != nil {
-		log.Printf("Unable to run request: %v\n", err)
-		return nil, err
-	}
-	return httpResp, nil
+// PostWithContext issues a POST request that is bound to ctx, allowing callers to cancel it or apply a deadline.
+func (bc baseClient) PostWithContext(ctx context.Context, baseURL string, endpoint string, body []byte) (*http.Response, error) {
+	fullUrl := fmt.Sprintf("%v/%v", baseURL, endpoint)
+	return bc.do(ctx, "POST", endpoint, fullUrl, body)
 }
 
-func (bc baseClient) Post(baseURL string, endpoint string, body []byte) (*http.Response, error) {
+func (bc baseClient) Put(baseURL string, endpoint string, body []byte) (*http.Response, error) {
+	return bc.PutWithContext(context.Background(), baseURL, endpoint, body)
+}
+
+// PutWithContext issues a PUT request that is bound to ctx, allowing callers to cancel it or apply a deadline.
+func (bc baseClient) PutWithContext(ctx context.Context, baseURL string, endpoint string, body []byte) (*http.Response, error) {
 	fullUrl := fmt.Sprintf("%v/%v", baseURL, endpoint)
+	return bc.do(ctx, "PUT", endpoint, fullUrl, body)
+}
 
-	if !stringInSlice(strings.ToLower(endpoint), restrictedEndpoints) {
-		log.Printf("Requesting POST --> %v : %v\n", fullUrl, string(body))
-	}
+func (bc baseClient) Patch(baseURL string, endpoint string, body []byte) (*http.Response, error) {
+	return bc.PatchWithContext(context.Background(), baseURL, endpoint, body)
+}
 
-	req, err := http.NewRequest("POST", fullUrl, bytes.NewBuffer(body))
-	if err != nil {
-		log.Printf("Unable to build POST request \"%v\": %v \n", fullUrl, err)
-		return nil, err
-	}
+// PatchWithContext issues a PATCH request that is bound to ctx, allowing callers to cancel it or apply a deadline.
+func (bc baseClient) PatchWithContext(ctx context.Context, baseURL string, endpoint string, body []byte) (*http.Response, error) {
+	fullUrl := fmt.Sprintf("%v/%v", baseURL, endpoint)
+	return bc.do(ctx, "PATCH", endpoint, fullUrl, body)
+}
 
-	req.Header = *bc.Headers
+func (bc baseClient) Delete(baseURL string, endpoint string, params string) (*http.Response, error) {
+	return bc.DeleteWithContext(context.Background(), baseURL, endpoint, params)
+}
 
-	resp, err := bc.HttpClient.Do(req)
-	if err != nil {
-		log.Printf("Error while running request \"%v\": %v", fullUrl, err)
-		return nil, err
+// DeleteWithContext issues a DELETE request that is bound to ctx, allowing callers to cancel it or apply a deadline.
+func (bc baseClient) DeleteWithContext(ctx context.Context, baseURL string, endpoint string, params string) (*http.Response, error) {
+	var fullURL string
+	if params != "" {
+		fullURL = fmt.Sprintf("%v/%v?%v", baseURL, endpoint, params)
+	} else {
+		fullURL = fmt.Sprintf("%v/%v", baseURL, endpoint)
 	}
-	return resp, err
+	return bc.do(ctx, "DELETE", endpoint, fullURL, nil)
 }
 
-func (bc baseClient) Put(baseURL string, endpoint string, body []byte) (*http.Response, error) {
-	fullUrl := fmt.Sprintf("%v/%v", baseURL, endpoint)
-	log.Printf("Requesting PUT --> %v : %v\n", fullUrl, string(body))
-	req, err := http.NewRequest("PUT", fullUrl, bytes.NewBuffer(body))
-	if err != nil {
-		log.Printf("Unable to build PUT request \"%v\": %v \n", fullUrl, err)
-		return nil, err
-	}
+// do runs the request, retrying it according to the client's RetryPolicy. The body is replayed on every attempt.
+// endpoint is the path relative to the base URL, used to label metrics.
+func (bc baseClient) do(ctx context.Context, method string, endpoint string, fullURL string, body []byte) (*http.Response, error) {
+	if bc.HttpClient == nil {
+		return nil, errors.New("client is not initialized; create it with NewTenableIO, NewTenableSC or NewNessus")
+	}
+	logger := bc.logger()
+	policy := bc.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+		if err != nil {
+			return nil, err
+		}
 
-	req.Header = *bc.Headers
+		req.Header = bc.cloneHeaders()
 
-	resp, err := bc.HttpClient.Do(req)
-	if err != nil {
-		log.Printf("Error while running request \"%v\": %v", fullUrl, err)
-		return nil, err
+		if err = bc.limiter.wait(ctx, logger, method, fullURL); err != nil {
+			return nil, err
+		}
+
+		if bc.debugEnabled() {
+			logger.Debug("Sending request", "method", method, "url", fullURL, "attempt", attempt,
+				"headers", redactHeaders(req.Header), "body", redactBody(body))
+		}
+		spanCtx, span := bc.startSpan(ctx, "HTTP "+method, endpointTemplate(endpoint),
+			Attribute{Key: AttrHTTPMethod, Value: method}, Attribute{Key: AttrAttempt, Value: attempt})
+		start := time.Now()
+		resp, err := bc.HttpClient.Do(req.WithContext(spanCtx))
+		bc.observe(method, endpoint, attempt, start, resp, err)
+		finishAttemptSpan(span, resp, err)
+		if err == nil {
+			logger.Debug("Received response", "method", method, "url", fullURL, "status", resp.StatusCode,
+				"request_id", requestID(resp.Header))
+		}
+		if attempt >= policy.maxAttempts() || !policy.shouldRetry(ctx, method, resp, err) {
+			if err != nil {
+				logger.Debug("Request failed", "method", method, "url", fullURL, "error", err)
+				return nil, err
+			}
+			return resp, nil
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			logger.Warn("Retrying request", "method", method, "url", fullURL, "status", resp.StatusCode,
+				"wait", wait, "next_attempt", attempt+1, "max_attempts", policy.maxAttempts())
+			drainAndClose(resp)
+		} else {
+			logger.Warn("Retrying request", "method", method, "url", fullURL, "error", err,
+				"wait", wait, "next_attempt", attempt+1, "max_attempts", policy.maxAttempts())
+		}
+		if err = sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
 	}
-	return resp, err
 }
 
-func (bc baseClient) Patch(baseURL string, endpoint string, body []byte) (*http.Response, error) {
-	fullUrl := fmt.Sprintf("%v/%v", baseURL, endpoint)
-	log.Printf("Requesting PATCH --> %v : %v\n", fullUrl, string(body))
-	req, err := http.NewRequest("PATCH", fullUrl, bytes.NewBuffer(body))
+// observe reports the attempt to the client's MetricsRecorder. Successful responses are reported when their body is
+// closed so the duration and size cover reading the body.
+func (bc baseClient) observe(method string, endpoint string, attempt int, start time.Time, resp *http.Response, err error) {
+	if bc.Metrics == nil {
+		return
+	}
+	metrics := RequestMetrics{
+		Product:  bc.product,
+		Method:   method,
+		Endpoint: endpointTemplate(endpoint),
+		Attempt:  attempt,
+	}
 	if err != nil {
-		log.Printf("Unable to build PATCH request \"%v\": %v \n", fullUrl, err)
-		return nil, err
+		metrics.Err = err
+		metrics.Duration = time.Since(start)
+		bc.Metrics.ObserveRequest(metrics)
+		return
 	}
+	metrics.StatusCode = resp.StatusCode
+	resp.Body = &meteredBody{ReadCloser: resp.Body, metrics: metrics, start: start, recorder: bc.Metrics}
+}
 
-	req.Header = *bc.Headers
-
-	resp, err := bc.HttpClient.Do(req)
+// readResponse reads and closes the response body. Any status outside of the 2xx range is returned as an *APIError
+// along with the body that was read.
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("Error while running request \"%v\": %v", fullUrl, err)
-		return nil, err
+		apiErr := newAPIError(resp, nil)
+		apiErr.Err = fmt.Errorf("unable to read response body: %w", err)
+		return nil, apiErr
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return body, newAPIError(resp, body)
 	}
-	return resp, err
+	return body, nil
 }
 
-func (bc baseClient) Delete(baseURL string, endpoint string, params string) (*http.Response, error) {
-	var fullURL string
-	if params != "" {
-		fullURL = fmt.Sprintf("%v/%v?%v", baseURL, endpoint, params)
-	} else {
-		fullURL = fmt.Sprintf("%v/%v", baseURL, endpoint)
-	}
-	log.Printf("Requesting DELETE --> %v\n", fullURL)
-	req, err := http.NewRequest("DELETE", fullURL, nil)
+// decodeResponse reads the response with readResponse and unmarshals the body into v.
+func decodeResponse(resp *http.Response, v interface{}) error {
+	body, err := readResponse(resp)
 	if err != nil {
-		log.Printf("Unable to build DELETE request \"%v\": %v\n", fullURL, err)
-		return nil, err
+		return err
 	}
+	if v == nil {
+		return nil
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		apiErr := newAPIError(resp, body)
+		apiErr.Err = fmt.Errorf("unable to unmarshal response: %w", err)
+		return apiErr
+	}
+	return nil
+}
 
-	req.Header = *bc.Headers
-
-	httpResp, err := bc.HttpClient.Do(req)
+// decodeSCResponse behaves like decodeResponse and additionally treats a non-zero error_code in the Tenable.sc
+// response envelope as a failure, since SC will occasionally report errors with a 200 status.
+func decodeSCResponse(resp *http.Response, v interface{}) error {
+	body, err := readResponse(resp)
 	if err != nil {
-		log.Printf("Unable to run request: %v\n", err)
-		return nil, err
+		return err
+	}
+	var envelope errorEnvelope
+	if json.Unmarshal(body, &envelope) == nil && envelope.ErrorCode != 0 {
+		return newAPIError(resp, body)
+	}
+	if v == nil {
+		return nil
 	}
-	return httpResp, nil
+	if err = json.Unmarshal(body, v); err != nil {
+		apiErr := newAPIError(resp, body)
+		apiErr.Err = fmt.Errorf("unable to unmarshal response: %w", err)
+		return apiErr
+	}
+	return nil
 }
 
 // TenableIO Client Base Functions
 
 func (io TenableIO) Get(endpoint string, params string) (*http.Response, error) {
-	resp, err := io.BaseClient.Get(io.BaseURL, endpoint, params)
+	return io.GetWithContext(context.Background(), endpoint, params)
+}
+
+func (io TenableIO) GetWithContext(ctx context.Context, endpoint string, params string) (*http.Response, error) {
+	resp, err := io.BaseClient.GetWithContext(ctx, io.BaseURL, endpoint, params)
 	if err != nil {
 		return nil, err
 	}
@@ -199,7 +341,11 @@ func (io TenableIO) Get(endpoint string, params string) (*http.Response, error)
 }
 
 func (io TenableIO) Post(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := io.BaseClient.Post(io.BaseURL, endpoint, body)
+	return io.PostWithContext(context.Background(), endpoint, body)
+}
+
+func (io TenableIO) PostWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	resp, err := io.BaseClient.PostWithContext(ctx, io.BaseURL, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +353,11 @@ func (io TenableIO) Post(endpoint string, body []byte) (*http.Response, error) {
 }
 
 func (io TenableIO) Put(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := io.BaseClient.Put(io.BaseURL, endpoint, body)
+	return io.PutWithContext(context.Background(), endpoint, body)
+}
+
+func (io TenableIO) PutWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	resp, err := io.BaseClient.PutWithContext(ctx, io.BaseURL, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
@@ -215,7 +365,11 @@ func (io TenableIO) Put(endpoint string, body []byte) (*http.Response, error) {
 }
 
 func (io TenableIO) Patch(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := io.BaseClient.Patch(io.BaseURL, endpoint, body)
+	return io.PatchWithContext(context.Background(), endpoint, body)
+}
+
+func (io TenableIO) PatchWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	resp, err := io.BaseClient.PatchWithContext(ctx, io.BaseURL, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
@@ -223,7 +377,11 @@ func (io TenableIO) Patch(endpoint string, body []byte) (*http.Response, error)
 }
 
 func (io TenableIO) Delete(endpoint string, params string) (*http.Response, error) {
-	resp, err := io.BaseClient.Delete(io.BaseURL, endpoint, params)
+	return io.DeleteWithContext(context.Background(), endpoint, params)
+}
+
+func (io TenableIO) DeleteWithContext(ctx context.Context, endpoint string, params string) (*http.Response, error) {
+	resp, err := io.BaseClient.DeleteWithContext(ctx, io.BaseURL, endpoint, params)
 	if err != nil {
 		return nil, err
 	}
@@ -233,49 +391,63 @@ func (io TenableIO) Delete(endpoint string, params string) (*http.Response, erro
 // TenableSC Client Base Functions
 
 func (sc TenableSC) Get(endpoint string, params string) (*http.Response, error) {
-	resp, err := sc.BaseClient.Get(sc.BaseURL, endpoint, params)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return sc.GetWithContext(context.Background(), endpoint, params)
+}
+
+func (sc TenableSC) GetWithContext(ctx context.Context, endpoint string, params string) (*http.Response, error) {
+	return sc.renewOnExpiry(ctx, endpoint, func() (*http.Response, error) {
+		return sc.BaseClient.GetWithContext(ctx, sc.BaseURL, endpoint, params)
+	})
 }
 
 func (sc TenableSC) Post(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := sc.BaseClient.Post(sc.BaseURL, endpoint, body)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return sc.PostWithContext(context.Background(), endpoint, body)
+}
+
+func (sc TenableSC) PostWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	return sc.renewOnExpiry(ctx, endpoint, func() (*http.Response, error) {
+		return sc.BaseClient.PostWithContext(ctx, sc.BaseURL, endpoint, body)
+	})
 }
 
 func (sc TenableSC) Put(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := sc.BaseClient.Put(sc.BaseURL, endpoint, body)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return sc.PutWithContext(context.Background(), endpoint, body)
+}
+
+func (sc TenableSC) PutWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	return sc.renewOnExpiry(ctx, endpoint, func() (*http.Response, error) {
+		return sc.BaseClient.PutWithContext(ctx, sc.BaseURL, endpoint, body)
+	})
 }
 
 func (sc TenableSC) Patch(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := sc.BaseClient.Patch(sc.BaseURL, endpoint, body)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return sc.PatchWithContext(context.Background(), endpoint, body)
+}
+
+func (sc TenableSC) PatchWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	return sc.renewOnExpiry(ctx, endpoint, func() (*http.Response, error) {
+		return sc.BaseClient.PatchWithContext(ctx, sc.BaseURL, endpoint, body)
+	})
 }
 
 func (sc TenableSC) Delete(endpoint string, params string) (*http.Response, error) {
-	resp, err := sc.BaseClient.Delete(sc.BaseURL, endpoint, params)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return sc.DeleteWithContext(context.Background(), endpoint, params)
+}
+
+func (sc TenableSC) DeleteWithContext(ctx context.Context, endpoint string, params string) (*http.Response, error) {
+	return sc.renewOnExpiry(ctx, endpoint, func() (*http.Response, error) {
+		return sc.BaseClient.DeleteWithContext(ctx, sc.BaseURL, endpoint, params)
+	})
 }
 
 // Nessus Client Base Functions
 
 func (n Nessus) Get(endpoint string, params string) (*http.Response, error) {
-	resp, err := n.BaseClient.Get(n.BaseURL, endpoint, params)
+	return n.GetWithContext(context.Background(), endpoint, params)
+}
+
+func (n Nessus) GetWithContext(ctx context.Context, endpoint string, params string) (*http.Response, error) {
+	resp, err := n.BaseClient.GetWithContext(ctx, n.BaseURL, endpoint, params)
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +455,11 @@ func (n Nessus) Get(endpoint string, params string) (*http.Response, error) {
 }
 
 func (n Nessus) Post(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := n.BaseClient.Post(n.BaseURL, endpoint, body)
+	return n.PostWithContext(context.Background(), endpoint, body)
+}
+
+func (n Nessus) PostWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	resp, err := n.BaseClient.PostWithContext(ctx, n.BaseURL, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
@@ -291,7 +467,11 @@ func (n Nessus) Post(endpoint string, body []byte) (*http.Response, error) {
 }
 
 func (n Nessus) Put(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := n.BaseClient.Put(n.BaseURL, endpoint, body)
+	return n.PutWithContext(context.Background(), endpoint, body)
+}
+
+func (n Nessus) PutWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	resp, err := n.BaseClient.PutWithContext(ctx, n.BaseURL, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
@@ -299,7 +479,11 @@ func (n Nessus) Put(endpoint string, body []byte) (*http.Response, error) {
 }
 
 func (n Nessus) Patch(endpoint string, body []byte) (*http.Response, error) {
-	resp, err := n.BaseClient.Patch(n.BaseURL, endpoint, body)
+	return n.PatchWithContext(context.Background(), endpoint, body)
+}
+
+func (n Nessus) PatchWithContext(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	resp, err := n.BaseClient.PatchWithContext(ctx, n.BaseURL, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
@@ -307,7 +491,11 @@ func (n Nessus) Patch(endpoint string, body []byte) (*http.Response, error) {
 }
 
 func (n Nessus) Delete(endpoint string, params string) (*http.Response, error) {
-	resp, err := n.BaseClient.Delete(n.BaseURL, endpoint, params)
+	return n.DeleteWithContext(context.Background(), endpoint, params)
+}
+
+func (n Nessus) DeleteWithContext(ctx context.Context, endpoint string, params string) (*http.Response, error) {
+	resp, err := n.BaseClient.DeleteWithContext(ctx, n.BaseURL, endpoint, params)
 	if err != nil {
 		return nil, err
 	}
@@ -316,26 +504,77 @@ func (n Nessus) Delete(endpoint string, params string) (*http.Response, error) {
 
 // Structs
 
+// baseClient is shared by value between a client and everything created from it (exports, assets), so any state
+// that must be visible to all copies lives behind a pointer. Headers is guarded by headerMu and every request sends
+// its own copy, which makes clients safe for concurrent use; change headers with SetHeader rather than directly.
 type baseClient struct {
-	HttpClient *http.Client
-	Headers    *http.Header
+	HttpClient  *http.Client
+	Headers     *http.Header
+	RetryPolicy RetryPolicy
+	Logger      Logger
+	Metrics     MetricsRecorder
+	product     Product
+	tracer      Tracer
+	headerMu    *sync.RWMutex
+	limiter     *rateLimiter
+}
+
+// SetHeader replaces any existing values of key with value on every subsequent request made by the client and its
+// copies.
+func (bc baseClient) SetHeader(key string, value string) {
+	bc.lock()
+	defer bc.unlock()
+	bc.Headers.Set(key, value)
+}
+
+// DeleteHeader stops key from being sent on subsequent requests.
+func (bc baseClient) DeleteHeader(key string) {
+	bc.lock()
+	defer bc.unlock()
+	bc.Headers.Del(key)
+}
+
+func (bc baseClient) cloneHeaders() http.Header {
+	if bc.headerMu != nil {
+		bc.headerMu.RLock()
+		defer bc.headerMu.RUnlock()
+	}
+	return bc.Headers.Clone()
+}
+
+func (bc baseClient) lock() {
+	if bc.headerMu != nil {
+		bc.headerMu.Lock()
+	}
+}
+
+func (bc baseClient) unlock() {
+	if bc.headerMu != nil {
+		bc.headerMu.Unlock()
+	}
 }
 
+// TenableIO is safe for concurrent use by multiple goroutines.
 type TenableIO struct {
 	BaseClient baseClient
 	accessKey  string
 	secretKey  string
 	BaseURL    string
+	scannerID  int
 }
 
+// TenableSC is safe for concurrent use by multiple goroutines once logged in. Login and Logout replace the session
+// headers shared by every copy of the client, so they may be called again at any time without stacking headers.
 type TenableSC struct {
 	BaseClient baseClient
 	User       string
-	token      int
-	session    string
+	accessKey  string
+	secretKey  string
+	renewal    *sessionRenewal
 	BaseURL    string
 }
 
+// Nessus is safe for concurrent use by multiple goroutines.
 type Nessus struct {
 	BaseClient baseClient
 	accessKey  string
@@ -344,12 +583,3 @@ type Nessus struct {
 	Port       int
 	BaseURL    string
 }
-
-func stringInSlice(str string, list []string) bool {
-	for _, v := range list {
-		if v == str {
-			return true
-		}
-	}
-	return false
-}
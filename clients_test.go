@@ -0,0 +1,83 @@
+package go_tenable_test
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+// sessionPairChecker records every request whose X-SecurityCenter token and TNS_SESSIONID cookie came from different
+// logins. tenabletest.SCServer issues the cookie "session-<token>" with each token.
+type sessionPairChecker struct {
+	next http.RoundTripper
+
+	mu         sync.Mutex
+	requests   int
+	mismatched []string
+}
+
+func (c *sessionPairChecker) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := req.Header.Get("X-SecurityCenter")
+	cookie := req.Header.Get("Cookie")
+	want := ""
+	if token != "" {
+		want = "TNS_SESSIONID=session-" + token
+	}
+	c.mu.Lock()
+	c.requests++
+	if cookie != want || len(req.Header["X-Securitycenter"]) > 1 || strings.Count(cookie, "TNS_SESSIONID") > 1 {
+		c.mismatched = append(c.mismatched, req.Method+" "+req.URL.Path+": token "+token+", cookie "+cookie)
+	}
+	c.mu.Unlock()
+	return c.next.RoundTrip(req)
+}
+
+func TestTenableSCConcurrentRequestsDuringLogin(t *testing.T) {
+	srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+	defer srv.Close()
+	checker := &sessionPairChecker{next: srv.Client().Transport}
+	sc, err := go_tenable.NewTenableSC("", go_tenable.WithBaseURL(srv.URL+"/rest"), go_tenable.WithRoundTripper(checker))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sc.Login(tenabletest.SCUsername, tenabletest.SCPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				if _, err := sc.Login(tenabletest.SCUsername, tenabletest.SCPassword); err != nil {
+					t.Errorf("Login: %v", err)
+				}
+				// Logout fails when another goroutine's login has already replaced the session it ends
+				_ = sc.Logout()
+			}
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				// Requests sent between a Logout and the next Login are rejected; only the headers matter here
+				_, _ = sc.ListAssets()
+			}
+		}()
+	}
+	wg.Wait()
+
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	if len(checker.mismatched) > 0 {
+		t.Fatalf("%v of %v requests carried mismatched session headers, first: %v", len(checker.mismatched),
+			checker.requests, checker.mismatched[0])
+	}
+}
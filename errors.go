@@ -0,0 +1,141 @@
+package go_tenable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned whenever Tenable.io, Tenable.sc or Nessus responds with a failure. It carries the HTTP status
+// along with whatever error details the product included in the response body.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	RequestID  string
+
+	// ErrorCode and ErrorMsg are populated from the Tenable.sc response envelope.
+	ErrorCode int
+	ErrorMsg  string
+
+	// Message is populated from the Tenable.io and Nessus error bodies ("error" and "message" fields).
+	Message string
+
+	Body []byte
+	Err  error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v %v", e.Method, e.Endpoint)
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, ": HTTP %v", e.StatusCode)
+	}
+	if e.ErrorCode != 0 {
+		fmt.Fprintf(&b, ": error_code %v", e.ErrorCode)
+	}
+	if e.ErrorMsg != "" {
+		fmt.Fprintf(&b, ": %v", strings.TrimSpace(e.ErrorMsg))
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %v", e.Message)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request id %v)", e.RequestID)
+	}
+	return b.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Tenable.sc error_code values with a known meaning.
+const (
+	scErrorCodeInvalidToken = 74
+)
+
+// IsUnauthorized reports whether err is an APIError for a missing or rejected credential.
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.ErrorCode == scErrorCodeInvalidToken
+}
+
+// IsForbidden reports whether err is an APIError for a request the credential is not permitted to make.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err is an APIError for an object that does not exist.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err is an APIError caused by the product throttling requests.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsServerError reports whether err is an APIError with a 5xx status.
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// errorEnvelope covers the error fields of all three products. Tenable.sc wraps every response with error_code and
+// error_msg, while Tenable.io and Nessus return "error" and/or "message" on failures.
+type errorEnvelope struct {
+	ErrorCode int    `json:"error_code"`
+	ErrorMsg  string `json:"error_msg"`
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  requestID(resp.Header),
+		Body:       body,
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+
+	var envelope errorEnvelope
+	if json.Unmarshal(body, &envelope) == nil {
+		apiErr.ErrorCode = envelope.ErrorCode
+		apiErr.ErrorMsg = envelope.ErrorMsg
+		switch {
+		case envelope.Error != "" && envelope.Message != "":
+			apiErr.Message = fmt.Sprintf("%v: %v", envelope.Error, envelope.Message)
+		case envelope.Message != "":
+			apiErr.Message = envelope.Message
+		default:
+			apiErr.Message = envelope.Error
+		}
+	}
+	return apiErr
+}
+
+func requestID(header http.Header) string {
+	for _, key := range []string{"X-Request-Uuid", "X-Request-Id"} {
+		if v := header.Get(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,821 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// The Fake types are in-memory test doubles for the service interfaces in services.go. Each method calls the
+// matching Func field when it is set and otherwise returns zero values (or an empty iterator). The plain and
+// WithContext forms of a method share one Func field, and calls to either are counted under the plain method name.
+//
+//	fake := &go_tenable.FakeTenableSC{
+//		ListAssetsFunc: func(ctx context.Context) (go_tenable.AssetListResponse, error) {
+//			return go_tenable.AssetListResponse{}, &go_tenable.APIError{StatusCode: 403}
+//		},
+//	}
+//	runJob(fake)
+//	fmt.Println(fake.CallCount("ListAssets"))
+
+// fakeCalls counts the calls made to a fake, keyed by method name.
+type fakeCalls struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *fakeCalls) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[method]++
+}
+
+// CallCount returns how many times method was called on the fake.
+func (c *fakeCalls) CallCount(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+type FakeTenableIO struct {
+	ListAgentsFunc            func(ctx context.Context) ([]AgentResponse, error)
+	ListEventsFunc            func(ctx context.Context, filter EventFilter) ([]Event, error)
+	ListScansFunc             func(ctx context.Context) (ScanListResponse, error)
+	AgentsFunc                func(ctx context.Context, pageSize int) *AgentIterator
+	EventsFunc                func(ctx context.Context, filter EventFilter, pageSize int) *EventIterator
+	ScansFunc                 func(ctx context.Context) *ScanIterator
+	ListExportsFunc           func(ctx context.Context, exportType string) ([]ExportStatusResponse, error)
+	SyncAssetsFunc            func(ctx context.Context, opts SyncOptions, fn func(ExportedAsset) error) (SyncResult, error)
+	NewExportServiceFunc      func(exportType string) IOExportService
+	ResumeExportServiceFunc   func(state ExportState) IOExportService
+	SyncVulnsFunc             func(ctx context.Context, opts SyncOptions, fn func(VulnerabilityFinding) error) (SyncResult, error)
+	GetAgentFunc              func(ctx context.Context, agentID int) (Agent, error)
+	UnlinkAgentFunc           func(ctx context.Context, agentID int) error
+	UnlinkAgentsFunc          func(ctx context.Context, agentIDs []int) (AgentBulkTask, error)
+	AgentTaskStatusFunc       func(ctx context.Context, task AgentBulkTask) (AgentBulkTask, error)
+	WaitForAgentTaskFunc      func(ctx context.Context, task AgentBulkTask, pollInterval time.Duration) (AgentBulkTask, error)
+	ListAgentGroupsFunc       func(ctx context.Context) ([]AgentGroup, error)
+	CreateAgentGroupFunc      func(ctx context.Context, name string) (AgentGroup, error)
+	DeleteAgentGroupFunc      func(ctx context.Context, groupID int) error
+	AddAgentToGroupFunc       func(ctx context.Context, groupID int, agentID int) error
+	RemoveAgentFromGroupFunc  func(ctx context.Context, groupID int, agentID int) error
+	AddAgentsToGroupFunc      func(ctx context.Context, groupID int, agentIDs []int) (AgentBulkTask, error)
+	RemoveAgentsFromGroupFunc func(ctx context.Context, groupID int, agentIDs []int) (AgentBulkTask, error)
+
+	fakeCalls
+}
+
+// NewExportService returns NewExportServiceFunc's export, or a new FakeExport when it is not set.
+func (f *FakeTenableIO) NewExportService(exportType string) IOExportService {
+	f.record("NewExportService")
+	if f.NewExportServiceFunc != nil {
+		return f.NewExportServiceFunc(exportType)
+	}
+	return &FakeExport{}
+}
+
+// ResumeExportService returns ResumeExportServiceFunc's export, or a new FakeExport when it is not set.
+func (f *FakeTenableIO) ResumeExportService(state ExportState) IOExportService {
+	f.record("ResumeExportService")
+	if f.ResumeExportServiceFunc != nil {
+		return f.ResumeExportServiceFunc(state)
+	}
+	return &FakeExport{}
+}
+
+func (f *FakeTenableIO) SyncAssets(opts SyncOptions, fn func(ExportedAsset) error) (SyncResult, error) {
+	return f.SyncAssetsWithContext(context.Background(), opts, fn)
+}
+
+func (f *FakeTenableIO) SyncAssetsWithContext(ctx context.Context, opts SyncOptions, fn func(ExportedAsset) error) (SyncResult, error) {
+	f.record("SyncAssets")
+	if f.SyncAssetsFunc != nil {
+		return f.SyncAssetsFunc(ctx, opts, fn)
+	}
+	var zero SyncResult
+	return zero, nil
+}
+
+func (f *FakeTenableIO) SyncVulns(opts SyncOptions, fn func(VulnerabilityFinding) error) (SyncResult, error) {
+	return f.SyncVulnsWithContext(context.Background(), opts, fn)
+}
+
+func (f *FakeTenableIO) SyncVulnsWithContext(ctx context.Context, opts SyncOptions, fn func(VulnerabilityFinding) error) (SyncResult, error) {
+	f.record("SyncVulns")
+	if f.SyncVulnsFunc != nil {
+		return f.SyncVulnsFunc(ctx, opts, fn)
+	}
+	var zero SyncResult
+	return zero, nil
+}
+
+func (f *FakeTenableIO) ListExports(exportType string) ([]ExportStatusResponse, error) {
+	return f.ListExportsWithContext(context.Background(), exportType)
+}
+
+func (f *FakeTenableIO) ListExportsWithContext(ctx context.Context, exportType string) ([]ExportStatusResponse, error) {
+	f.record("ListExports")
+	if f.ListExportsFunc != nil {
+		return f.ListExportsFunc(ctx, exportType)
+	}
+	var zero []ExportStatusResponse
+	return zero, nil
+}
+
+func (f *FakeTenableIO) ListAgents() ([]AgentResponse, error) {
+	return f.ListAgentsWithContext(context.Background())
+}
+
+func (f *FakeTenableIO) ListAgentsWithContext(ctx context.Context) ([]AgentResponse, error) {
+	f.record("ListAgents")
+	if f.ListAgentsFunc != nil {
+		return f.ListAgentsFunc(ctx)
+	}
+	var zero []AgentResponse
+	return zero, nil
+}
+
+func (f *FakeTenableIO) GetAgent(agentID int) (Agent, error) {
+	return f.GetAgentWithContext(context.Background(), agentID)
+}
+
+func (f *FakeTenableIO) GetAgentWithContext(ctx context.Context, agentID int) (Agent, error) {
+	f.record("GetAgent")
+	if f.GetAgentFunc != nil {
+		return f.GetAgentFunc(ctx, agentID)
+	}
+	var zero Agent
+	return zero, nil
+}
+
+func (f *FakeTenableIO) UnlinkAgent(agentID int) error {
+	return f.UnlinkAgentWithContext(context.Background(), agentID)
+}
+
+func (f *FakeTenableIO) UnlinkAgentWithContext(ctx context.Context, agentID int) error {
+	f.record("UnlinkAgent")
+	if f.UnlinkAgentFunc != nil {
+		return f.UnlinkAgentFunc(ctx, agentID)
+	}
+	return nil
+}
+
+func (f *FakeTenableIO) UnlinkAgents(agentIDs []int) (AgentBulkTask, error) {
+	return f.UnlinkAgentsWithContext(context.Background(), agentIDs)
+}
+
+func (f *FakeTenableIO) UnlinkAgentsWithContext(ctx context.Context, agentIDs []int) (AgentBulkTask, error) {
+	f.record("UnlinkAgents")
+	if f.UnlinkAgentsFunc != nil {
+		return f.UnlinkAgentsFunc(ctx, agentIDs)
+	}
+	var zero AgentBulkTask
+	return zero, nil
+}
+
+func (f *FakeTenableIO) AgentTaskStatus(task AgentBulkTask) (AgentBulkTask, error) {
+	return f.AgentTaskStatusWithContext(context.Background(), task)
+}
+
+func (f *FakeTenableIO) AgentTaskStatusWithContext(ctx context.Context, task AgentBulkTask) (AgentBulkTask, error) {
+	f.record("AgentTaskStatus")
+	if f.AgentTaskStatusFunc != nil {
+		return f.AgentTaskStatusFunc(ctx, task)
+	}
+	var zero AgentBulkTask
+	return zero, nil
+}
+
+func (f *FakeTenableIO) WaitForAgentTask(task AgentBulkTask, pollInterval time.Duration) (AgentBulkTask, error) {
+	return f.WaitForAgentTaskWithContext(context.Background(), task, pollInterval)
+}
+
+func (f *FakeTenableIO) WaitForAgentTaskWithContext(ctx context.Context, task AgentBulkTask, pollInterval time.Duration) (AgentBulkTask, error) {
+	f.record("WaitForAgentTask")
+	if f.WaitForAgentTaskFunc != nil {
+		return f.WaitForAgentTaskFunc(ctx, task, pollInterval)
+	}
+	var zero AgentBulkTask
+	return zero, nil
+}
+
+func (f *FakeTenableIO) ListAgentGroups() ([]AgentGroup, error) {
+	return f.ListAgentGroupsWithContext(context.Background())
+}
+
+func (f *FakeTenableIO) ListAgentGroupsWithContext(ctx context.Context) ([]AgentGroup, error) {
+	f.record("ListAgentGroups")
+	if f.ListAgentGroupsFunc != nil {
+		return f.ListAgentGroupsFunc(ctx)
+	}
+	var zero []AgentGroup
+	return zero, nil
+}
+
+func (f *FakeTenableIO) CreateAgentGroup(name string) (AgentGroup, error) {
+	return f.CreateAgentGroupWithContext(context.Background(), name)
+}
+
+func (f *FakeTenableIO) CreateAgentGroupWithContext(ctx context.Context, name string) (AgentGroup, error) {
+	f.record("CreateAgentGroup")
+	if f.CreateAgentGroupFunc != nil {
+		return f.CreateAgentGroupFunc(ctx, name)
+	}
+	var zero AgentGroup
+	return zero, nil
+}
+
+func (f *FakeTenableIO) DeleteAgentGroup(groupID int) error {
+	return f.DeleteAgentGroupWithContext(context.Background(), groupID)
+}
+
+func (f *FakeTenableIO) DeleteAgentGroupWithContext(ctx context.Context, groupID int) error {
+	f.record("DeleteAgentGroup")
+	if f.DeleteAgentGroupFunc != nil {
+		return f.DeleteAgentGroupFunc(ctx, groupID)
+	}
+	return nil
+}
+
+func (f *FakeTenableIO) AddAgentToGroup(groupID int, agentID int) error {
+	return f.AddAgentToGroupWithContext(context.Background(), groupID, agentID)
+}
+
+func (f *FakeTenableIO) AddAgentToGroupWithContext(ctx context.Context, groupID int, agentID int) error {
+	f.record("AddAgentToGroup")
+	if f.AddAgentToGroupFunc != nil {
+		return f.AddAgentToGroupFunc(ctx, groupID, agentID)
+	}
+	return nil
+}
+
+func (f *FakeTenableIO) RemoveAgentFromGroup(groupID int, agentID int) error {
+	return f.RemoveAgentFromGroupWithContext(context.Background(), groupID, agentID)
+}
+
+func (f *FakeTenableIO) RemoveAgentFromGroupWithContext(ctx context.Context, groupID int, agentID int) error {
+	f.record("RemoveAgentFromGroup")
+	if f.RemoveAgentFromGroupFunc != nil {
+		return f.RemoveAgentFromGroupFunc(ctx, groupID, agentID)
+	}
+	return nil
+}
+
+func (f *FakeTenableIO) AddAgentsToGroup(groupID int, agentIDs []int) (AgentBulkTask, error) {
+	return f.AddAgentsToGroupWithContext(context.Background(), groupID, agentIDs)
+}
+
+func (f *FakeTenableIO) AddAgentsToGroupWithContext(ctx context.Context, groupID int, agentIDs []int) (AgentBulkTask, error) {
+	f.record("AddAgentsToGroup")
+	if f.AddAgentsToGroupFunc != nil {
+		return f.AddAgentsToGroupFunc(ctx, groupID, agentIDs)
+	}
+	var zero AgentBulkTask
+	return zero, nil
+}
+
+func (f *FakeTenableIO) RemoveAgentsFromGroup(groupID int, agentIDs []int) (AgentBulkTask, error) {
+	return f.RemoveAgentsFromGroupWithContext(context.Background(), groupID, agentIDs)
+}
+
+func (f *FakeTenableIO) RemoveAgentsFromGroupWithContext(ctx context.Context, groupID int, agentIDs []int) (AgentBulkTask, error) {
+	f.record("RemoveAgentsFromGroup")
+	if f.RemoveAgentsFromGroupFunc != nil {
+		return f.RemoveAgentsFromGroupFunc(ctx, groupID, agentIDs)
+	}
+	var zero AgentBulkTask
+	return zero, nil
+}
+
+func (f *FakeTenableIO) ListEvents(filter EventFilter) ([]Event, error) {
+	return f.ListEventsWithContext(context.Background(), filter)
+}
+
+func (f *FakeTenableIO) ListEventsWithContext(ctx context.Context, filter EventFilter) ([]Event, error) {
+	f.record("ListEvents")
+	if f.ListEventsFunc != nil {
+		return f.ListEventsFunc(ctx, filter)
+	}
+	var zero []Event
+	return zero, nil
+}
+
+func (f *FakeTenableIO) ListScans() (ScanListResponse, error) {
+	return f.ListScansWithContext(context.Background())
+}
+
+func (f *FakeTenableIO) ListScansWithContext(ctx context.Context) (ScanListResponse, error) {
+	f.record("ListScans")
+	if f.ListScansFunc != nil {
+		return f.ListScansFunc(ctx)
+	}
+	var zero ScanListResponse
+	return zero, nil
+}
+
+func (f *FakeTenableIO) Agents(ctx context.Context, pageSize int) *AgentIterator {
+	f.record("Agents")
+	if f.AgentsFunc != nil {
+		return f.AgentsFunc(ctx, pageSize)
+	}
+	return NewStaticAgentIterator(nil, nil)
+}
+
+func (f *FakeTenableIO) Events(ctx context.Context, filter EventFilter, pageSize int) *EventIterator {
+	f.record("Events")
+	if f.EventsFunc != nil {
+		return f.EventsFunc(ctx, filter, pageSize)
+	}
+	return NewStaticEventIterator(nil, nil)
+}
+
+func (f *FakeTenableIO) Scans(ctx context.Context) *ScanIterator {
+	f.record("Scans")
+	if f.ScansFunc != nil {
+		return f.ScansFunc(ctx)
+	}
+	return NewStaticScanIterator(nil, nil)
+}
+
+type FakeExport struct {
+	RequestExportFunc           func(ctx context.Context, Payload []byte) (string, error)
+	RequestStatusFunc           func(ctx context.Context) (string, error)
+	DownloadChunkFunc           func(ctx context.Context, ChunkID int) (AssetChunkDownloadResponse, error)
+	StreamChunkFunc             func(ctx context.Context, ChunkID int, fn func(ExportedAsset) error) error
+	DownloadVulnChunkFunc       func(ctx context.Context, ChunkID int) ([]VulnerabilityFinding, error)
+	StreamVulnChunkFunc         func(ctx context.Context, ChunkID int, fn func(VulnerabilityFinding) error) error
+	DownloadComplianceChunkFunc func(ctx context.Context, ChunkID int) ([]ComplianceFinding, error)
+	StreamComplianceChunkFunc   func(ctx context.Context, ChunkID int, fn func(ComplianceFinding) error) error
+	RunFunc                     func(ctx context.Context, opts ExportRunOptions, fn func(ExportRecord) error) error
+	StreamFunc                  func(ctx context.Context, opts ExportRunOptions) (<-chan ExportRecord, <-chan error)
+	StateFunc                   func() ExportState
+	RequestStatusDetailFunc     func(ctx context.Context) (ExportStatusResponse, error)
+	CancelFunc                  func(ctx context.Context) (string, error)
+	GetUnprocessedChunksFunc    func() []int
+
+	fakeCalls
+}
+
+func (f *FakeExport) RequestExport(Payload []byte) (string, error) {
+	return f.RequestExportWithContext(context.Background(), Payload)
+}
+
+func (f *FakeExport) RequestExportWithContext(ctx context.Context, Payload []byte) (string, error) {
+	f.record("RequestExport")
+	if f.RequestExportFunc != nil {
+		return f.RequestExportFunc(ctx, Payload)
+	}
+	var zero string
+	return zero, nil
+}
+
+func (f *FakeExport) RequestStatus() (string, error) {
+	return f.RequestStatusWithContext(context.Background())
+}
+
+func (f *FakeExport) RequestStatusWithContext(ctx context.Context) (string, error) {
+	f.record("RequestStatus")
+	if f.RequestStatusFunc != nil {
+		return f.RequestStatusFunc(ctx)
+	}
+	var zero string
+	return zero, nil
+}
+
+func (f *FakeExport) DownloadChunk(ChunkID int) (AssetChunkDownloadResponse, error) {
+	return f.DownloadChunkWithContext(context.Background(), ChunkID)
+}
+
+func (f *FakeExport) DownloadChunkWithContext(ctx context.Context, ChunkID int) (AssetChunkDownloadResponse, error) {
+	f.record("DownloadChunk")
+	if f.DownloadChunkFunc != nil {
+		return f.DownloadChunkFunc(ctx, ChunkID)
+	}
+	var zero AssetChunkDownloadResponse
+	return zero, nil
+}
+
+func (f *FakeExport) StreamChunk(ChunkID int, fn func(ExportedAsset) error) error {
+	return f.StreamChunkWithContext(context.Background(), ChunkID, fn)
+}
+
+func (f *FakeExport) StreamChunkWithContext(ctx context.Context, ChunkID int, fn func(ExportedAsset) error) error {
+	f.record("StreamChunk")
+	if f.StreamChunkFunc != nil {
+		return f.StreamChunkFunc(ctx, ChunkID, fn)
+	}
+	return nil
+}
+
+func (f *FakeExport) DownloadVulnChunk(ChunkID int) ([]VulnerabilityFinding, error) {
+	return f.DownloadVulnChunkWithContext(context.Background(), ChunkID)
+}
+
+func (f *FakeExport) DownloadVulnChunkWithContext(ctx context.Context, ChunkID int) ([]VulnerabilityFinding, error) {
+	f.record("DownloadVulnChunk")
+	if f.DownloadVulnChunkFunc != nil {
+		return f.DownloadVulnChunkFunc(ctx, ChunkID)
+	}
+	var zero []VulnerabilityFinding
+	return zero, nil
+}
+
+func (f *FakeExport) StreamVulnChunk(ChunkID int, fn func(VulnerabilityFinding) error) error {
+	return f.StreamVulnChunkWithContext(context.Background(), ChunkID, fn)
+}
+
+func (f *FakeExport) StreamVulnChunkWithContext(ctx context.Context, ChunkID int, fn func(VulnerabilityFinding) error) error {
+	f.record("StreamVulnChunk")
+	if f.StreamVulnChunkFunc != nil {
+		return f.StreamVulnChunkFunc(ctx, ChunkID, fn)
+	}
+	return nil
+}
+
+func (f *FakeExport) DownloadComplianceChunk(ChunkID int) ([]ComplianceFinding, error) {
+	return f.DownloadComplianceChunkWithContext(context.Background(), ChunkID)
+}
+
+func (f *FakeExport) DownloadComplianceChunkWithContext(ctx context.Context, ChunkID int) ([]ComplianceFinding, error) {
+	f.record("DownloadComplianceChunk")
+	if f.DownloadComplianceChunkFunc != nil {
+		return f.DownloadComplianceChunkFunc(ctx, ChunkID)
+	}
+	var zero []ComplianceFinding
+	return zero, nil
+}
+
+func (f *FakeExport) StreamComplianceChunk(ChunkID int, fn func(ComplianceFinding) error) error {
+	return f.StreamComplianceChunkWithContext(context.Background(), ChunkID, fn)
+}
+
+func (f *FakeExport) StreamComplianceChunkWithContext(ctx context.Context, ChunkID int, fn func(ComplianceFinding) error) error {
+	f.record("StreamComplianceChunk")
+	if f.StreamComplianceChunkFunc != nil {
+		return f.StreamComplianceChunkFunc(ctx, ChunkID, fn)
+	}
+	return nil
+}
+
+func (f *FakeExport) Run(opts ExportRunOptions, fn func(ExportRecord) error) error {
+	return f.RunWithContext(context.Background(), opts, fn)
+}
+
+func (f *FakeExport) RunWithContext(ctx context.Context, opts ExportRunOptions, fn func(ExportRecord) error) error {
+	f.record("Run")
+	if f.RunFunc != nil {
+		return f.RunFunc(ctx, opts, fn)
+	}
+	return nil
+}
+
+func (f *FakeExport) Stream(opts ExportRunOptions) (<-chan ExportRecord, <-chan error) {
+	return f.StreamWithContext(context.Background(), opts)
+}
+
+// StreamWithContext returns closed channels when StreamFunc is not set, so readers finish straight away.
+func (f *FakeExport) StreamWithContext(ctx context.Context, opts ExportRunOptions) (<-chan ExportRecord, <-chan error) {
+	f.record("Stream")
+	if f.StreamFunc != nil {
+		return f.StreamFunc(ctx, opts)
+	}
+	records := make(chan ExportRecord)
+	errc := make(chan error)
+	close(records)
+	close(errc)
+	return records, errc
+}
+
+func (f *FakeExport) State() ExportState {
+	f.record("State")
+	if f.StateFunc != nil {
+		return f.StateFunc()
+	}
+	var zero ExportState
+	return zero
+}
+
+func (f *FakeExport) RequestStatusDetail() (ExportStatusResponse, error) {
+	return f.RequestStatusDetailWithContext(context.Background())
+}
+
+func (f *FakeExport) RequestStatusDetailWithContext(ctx context.Context) (ExportStatusResponse, error) {
+	f.record("RequestStatusDetail")
+	if f.RequestStatusDetailFunc != nil {
+		return f.RequestStatusDetailFunc(ctx)
+	}
+	var zero ExportStatusResponse
+	return zero, nil
+}
+
+func (f *FakeExport) Cancel() (string, error) {
+	return f.CancelWithContext(context.Background())
+}
+
+func (f *FakeExport) CancelWithContext(ctx context.Context) (string, error) {
+	f.record("Cancel")
+	if f.CancelFunc != nil {
+		return f.CancelFunc(ctx)
+	}
+	var zero string
+	return zero, nil
+}
+
+func (f *FakeExport) GetUnprocessedChunks() []int {
+	f.record("GetUnprocessedChunks")
+	if f.GetUnprocessedChunksFunc != nil {
+		return f.GetUnprocessedChunksFunc()
+	}
+	return nil
+}
+
+type FakeTenableSC struct {
+	LoginFunc                   func(ctx context.Context, scUser string, scPassword string) (*TokenResponse, error)
+	LogoutFunc                  func(ctx context.Context) error
+	ListAssetsFunc              func(ctx context.Context) (AssetListResponse, error)
+	ListRepositoriesFunc        func(ctx context.Context) (RepoListResponse, error)
+	RepositoryDetailFunc        func(ctx context.Context, RepoId int) (RepoDetailResponse, error)
+	ListRiskAcceptanceRulesFunc func(ctx context.Context) (AcceptRiskRuleResponse, error)
+	ListRiskRecastRulesFunc     func(ctx context.Context) (RecastRiskRuleResponse, error)
+	QueryAnalysisFunc           func(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int) (AnalysisResponse, error)
+	StreamAnalysisFunc          func(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int, fn func(json.RawMessage) error) (AnalysisResponse, error)
+	AnalysisFunc                func(ctx context.Context, query AnalysisQuery, pageSize int) *AnalysisIterator
+	NewStaticIPAssetServiceFunc func(Name string, IPAddresses string, Description string, Tag string) SCStaticIPAssetService
+
+	fakeCalls
+}
+
+func (f *FakeTenableSC) Login(scUser string, scPassword string) (*TokenResponse, error) {
+	return f.LoginWithContext(context.Background(), scUser, scPassword)
+}
+
+func (f *FakeTenableSC) LoginWithContext(ctx context.Context, scUser string, scPassword string) (*TokenResponse, error) {
+	f.record("Login")
+	if f.LoginFunc != nil {
+		return f.LoginFunc(ctx, scUser, scPassword)
+	}
+	var zero *TokenResponse
+	return zero, nil
+}
+
+func (f *FakeTenableSC) Logout() error {
+	return f.LogoutWithContext(context.Background())
+}
+
+func (f *FakeTenableSC) LogoutWithContext(ctx context.Context) error {
+	f.record("Logout")
+	if f.LogoutFunc != nil {
+		return f.LogoutFunc(ctx)
+	}
+	return nil
+}
+
+func (f *FakeTenableSC) ListAssets() (AssetListResponse, error) {
+	return f.ListAssetsWithContext(context.Background())
+}
+
+func (f *FakeTenableSC) ListAssetsWithContext(ctx context.Context) (AssetListResponse, error) {
+	f.record("ListAssets")
+	if f.ListAssetsFunc != nil {
+		return f.ListAssetsFunc(ctx)
+	}
+	var zero AssetListResponse
+	return zero, nil
+}
+
+func (f *FakeTenableSC) ListRepositories() (RepoListResponse, error) {
+	return f.ListRepositoriesWithContext(context.Background())
+}
+
+func (f *FakeTenableSC) ListRepositoriesWithContext(ctx context.Context) (RepoListResponse, error) {
+	f.record("ListRepositories")
+	if f.ListRepositoriesFunc != nil {
+		return f.ListRepositoriesFunc(ctx)
+	}
+	var zero RepoListResponse
+	return zero, nil
+}
+
+func (f *FakeTenableSC) RepositoryDetail(RepoId int) (RepoDetailResponse, error) {
+	return f.RepositoryDetailWithContext(context.Background(), RepoId)
+}
+
+func (f *FakeTenableSC) RepositoryDetailWithContext(ctx context.Context, RepoId int) (RepoDetailResponse, error) {
+	f.record("RepositoryDetail")
+	if f.RepositoryDetailFunc != nil {
+		return f.RepositoryDetailFunc(ctx, RepoId)
+	}
+	var zero RepoDetailResponse
+	return zero, nil
+}
+
+func (f *FakeTenableSC) ListRiskAcceptanceRules() (AcceptRiskRuleResponse, error) {
+	return f.ListRiskAcceptanceRulesWithContext(context.Background())
+}
+
+func (f *FakeTenableSC) ListRiskAcceptanceRulesWithContext(ctx context.Context) (AcceptRiskRuleResponse, error) {
+	f.record("ListRiskAcceptanceRules")
+	if f.ListRiskAcceptanceRulesFunc != nil {
+		return f.ListRiskAcceptanceRulesFunc(ctx)
+	}
+	var zero AcceptRiskRuleResponse
+	return zero, nil
+}
+
+func (f *FakeTenableSC) ListRiskRecastRules() (RecastRiskRuleResponse, error) {
+	return f.ListRiskRecastRulesWithContext(context.Background())
+}
+
+func (f *FakeTenableSC) ListRiskRecastRulesWithContext(ctx context.Context) (RecastRiskRuleResponse, error) {
+	f.record("ListRiskRecastRules")
+	if f.ListRiskRecastRulesFunc != nil {
+		return f.ListRiskRecastRulesFunc(ctx)
+	}
+	var zero RecastRiskRuleResponse
+	return zero, nil
+}
+
+func (f *FakeTenableSC) QueryAnalysis(query AnalysisQuery, startOffset int, endOffset int) (AnalysisResponse, error) {
+	return f.QueryAnalysisWithContext(context.Background(), query, startOffset, endOffset)
+}
+
+func (f *FakeTenableSC) QueryAnalysisWithContext(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int) (AnalysisResponse, error) {
+	f.record("QueryAnalysis")
+	if f.QueryAnalysisFunc != nil {
+		return f.QueryAnalysisFunc(ctx, query, startOffset, endOffset)
+	}
+	var zero AnalysisResponse
+	return zero, nil
+}
+
+func (f *FakeTenableSC) StreamAnalysis(query AnalysisQuery, startOffset int, endOffset int, fn func(json.RawMessage) error) (AnalysisResponse, error) {
+	return f.StreamAnalysisWithContext(context.Background(), query, startOffset, endOffset, fn)
+}
+
+func (f *FakeTenableSC) StreamAnalysisWithContext(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int, fn func(json.RawMessage) error) (AnalysisResponse, error) {
+	f.record("StreamAnalysis")
+	if f.StreamAnalysisFunc != nil {
+		return f.StreamAnalysisFunc(ctx, query, startOffset, endOffset, fn)
+	}
+	var zero AnalysisResponse
+	return zero, nil
+}
+
+func (f *FakeTenableSC) Analysis(ctx context.Context, query AnalysisQuery, pageSize int) *AnalysisIterator {
+	f.record("Analysis")
+	if f.AnalysisFunc != nil {
+		return f.AnalysisFunc(ctx, query, pageSize)
+	}
+	return NewStaticAnalysisIterator(nil, nil)
+}
+
+// NewStaticIPAssetService returns NewStaticIPAssetServiceFunc's asset, or a FakeStaticIPAsset holding the given
+// values when it is not set.
+func (f *FakeTenableSC) NewStaticIPAssetService(Name string, IPAddresses string, Description string, Tag string) SCStaticIPAssetService {
+	f.record("NewStaticIPAssetService")
+	if f.NewStaticIPAssetServiceFunc != nil {
+		return f.NewStaticIPAssetServiceFunc(Name, IPAddresses, Description, Tag)
+	}
+	asset := TenableSC{}.NewStaticIPAsset(Name, IPAddresses, Description, Tag)
+	return &FakeStaticIPAsset{AssetFunc: func() StaticIPAsset { return asset }}
+}
+
+type FakeStaticIPAsset struct {
+	AssetFunc       func() StaticIPAsset
+	CreateFunc      func(ctx context.Context, sc TenableSC) (StaticIPCreateResponse, error)
+	EditFunc        func(ctx context.Context, sc TenableSC) error
+	ViewFunc        func(ctx context.Context, sc TenableSC) error
+	CalculatingFunc func(ctx context.Context, sc TenableSC) (bool, error)
+	DeleteFunc      func(ctx context.Context, sc TenableSC) error
+
+	fakeCalls
+}
+
+func (f *FakeStaticIPAsset) Asset() StaticIPAsset {
+	f.record("Asset")
+	if f.AssetFunc != nil {
+		return f.AssetFunc()
+	}
+	var zero StaticIPAsset
+	return zero
+}
+
+func (f *FakeStaticIPAsset) Create(sc TenableSC) (StaticIPCreateResponse, error) {
+	return f.CreateWithContext(context.Background(), sc)
+}
+
+func (f *FakeStaticIPAsset) CreateWithContext(ctx context.Context, sc TenableSC) (StaticIPCreateResponse, error) {
+	f.record("Create")
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, sc)
+	}
+	var zero StaticIPCreateResponse
+	return zero, nil
+}
+
+func (f *FakeStaticIPAsset) Edit(sc TenableSC) error {
+	return f.EditWithContext(context.Background(), sc)
+}
+
+func (f *FakeStaticIPAsset) EditWithContext(ctx context.Context, sc TenableSC) error {
+	f.record("Edit")
+	if f.EditFunc != nil {
+		return f.EditFunc(ctx, sc)
+	}
+	return nil
+}
+
+func (f *FakeStaticIPAsset) View(sc TenableSC) error {
+	return f.ViewWithContext(context.Background(), sc)
+}
+
+func (f *FakeStaticIPAsset) ViewWithContext(ctx context.Context, sc TenableSC) error {
+	f.record("View")
+	if f.ViewFunc != nil {
+		return f.ViewFunc(ctx, sc)
+	}
+	return nil
+}
+
+func (f *FakeStaticIPAsset) Calculating(sc TenableSC) (bool, error) {
+	return f.CalculatingWithContext(context.Background(), sc)
+}
+
+func (f *FakeStaticIPAsset) CalculatingWithContext(ctx context.Context, sc TenableSC) (bool, error) {
+	f.record("Calculating")
+	if f.CalculatingFunc != nil {
+		return f.CalculatingFunc(ctx, sc)
+	}
+	return false, nil
+}
+
+func (f *FakeStaticIPAsset) Delete(sc TenableSC) error {
+	return f.DeleteWithContext(context.Background(), sc)
+}
+
+func (f *FakeStaticIPAsset) DeleteWithContext(ctx context.Context, sc TenableSC) error {
+	f.record("Delete")
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, sc)
+	}
+	return nil
+}
+
+type FakeNessus struct {
+	GetStatusFunc      func(ctx context.Context) (StatusResponse, error)
+	GetPropertiesFunc  func(ctx context.Context) (PropertiesResponse, error)
+	GetHealthStatsFunc func(ctx context.Context, count int) (ScannerSettingsResponse, error)
+
+	fakeCalls
+}
+
+func (f *FakeNessus) GetStatus() (StatusResponse, error) {
+	return f.GetStatusWithContext(context.Background())
+}
+
+func (f *FakeNessus) GetStatusWithContext(ctx context.Context) (StatusResponse, error) {
+	f.record("GetStatus")
+	if f.GetStatusFunc != nil {
+		return f.GetStatusFunc(ctx)
+	}
+	var zero StatusResponse
+	return zero, nil
+}
+
+func (f *FakeNessus) GetProperties() (PropertiesResponse, error) {
+	return f.GetPropertiesWithContext(context.Background())
+}
+
+func (f *FakeNessus) GetPropertiesWithContext(ctx context.Context) (PropertiesResponse, error) {
+	f.record("GetProperties")
+	if f.GetPropertiesFunc != nil {
+		return f.GetPropertiesFunc(ctx)
+	}
+	var zero PropertiesResponse
+	return zero, nil
+}
+
+func (f *FakeNessus) GetHealthStats(count int) (ScannerSettingsResponse, error) {
+	return f.GetHealthStatsWithContext(context.Background(), count)
+}
+
+func (f *FakeNessus) GetHealthStatsWithContext(ctx context.Context, count int) (ScannerSettingsResponse, error) {
+	f.record("GetHealthStats")
+	if f.GetHealthStatsFunc != nil {
+		return f.GetHealthStatsFunc(ctx, count)
+	}
+	var zero ScannerSettingsResponse
+	return zero, nil
+}
+
+var (
+	_ TenableIOService       = (*FakeTenableIO)(nil)
+	_ IOExportService        = (*FakeExport)(nil)
+	_ TenableSCService       = (*FakeTenableSC)(nil)
+	_ SCStaticIPAssetService = (*FakeStaticIPAsset)(nil)
+	_ NessusService          = (*FakeNessus)(nil)
+)
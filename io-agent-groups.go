@@ -0,0 +1,150 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+func (io *TenableIO) ListAgentGroups() ([]AgentGroup, error) {
+	return io.ListAgentGroupsWithContext(context.Background())
+}
+
+func (io *TenableIO) ListAgentGroupsWithContext(ctx context.Context) (groups []AgentGroup, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.ListAgentGroups", "scanners/{id}/agent-groups")
+	defer func() { endSpan(span, err) }()
+
+	resp, err := io.GetWithContext(ctx, io.scannerPath("agent-groups"), "")
+	if err != nil {
+		return nil, err
+	}
+	var listRes AgentGroupListResponse
+	if err = decodeResponse(resp, &listRes); err != nil {
+		return nil, err
+	}
+	return listRes.Groups, nil
+}
+
+func (io *TenableIO) CreateAgentGroup(name string) (AgentGroup, error) {
+	return io.CreateAgentGroupWithContext(context.Background(), name)
+}
+
+func (io *TenableIO) CreateAgentGroupWithContext(ctx context.Context, name string) (group AgentGroup, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.CreateAgentGroup", "scanners/{id}/agent-groups")
+	defer func() { endSpan(span, err) }()
+
+	io.BaseClient.logger().Info("Creating agent group", "name", name)
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return group, err
+	}
+	resp, err := io.PostWithContext(ctx, io.scannerPath("agent-groups"), body)
+	if err != nil {
+		return group, err
+	}
+	err = decodeResponse(resp, &group)
+	return group, err
+}
+
+func (io *TenableIO) DeleteAgentGroup(groupID int) error {
+	return io.DeleteAgentGroupWithContext(context.Background(), groupID)
+}
+
+// DeleteAgentGroupWithContext deletes an agent group. The agents in it stay linked.
+func (io *TenableIO) DeleteAgentGroupWithContext(ctx context.Context, groupID int) (err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.DeleteAgentGroup", "scanners/{id}/agent-groups/{id}",
+		Attribute{Key: AttrAgentGroupID, Value: groupID})
+	defer func() { endSpan(span, err) }()
+
+	io.BaseClient.logger().Info("Deleting agent group", "group_id", groupID)
+	resp, err := io.DeleteWithContext(ctx, io.scannerPath(fmt.Sprintf("agent-groups/%v", groupID)), "")
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, nil)
+}
+
+func (io *TenableIO) AddAgentToGroup(groupID int, agentID int) error {
+	return io.AddAgentToGroupWithContext(context.Background(), groupID, agentID)
+}
+
+func (io *TenableIO) AddAgentToGroupWithContext(ctx context.Context, groupID int, agentID int) (err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.AddAgentToGroup", "scanners/{id}/agent-groups/{id}/agents/{id}",
+		Attribute{Key: AttrAgentGroupID, Value: groupID}, Attribute{Key: AttrAgentID, Value: agentID})
+	defer func() { endSpan(span, err) }()
+
+	resp, err := io.PutWithContext(ctx, io.scannerPath(fmt.Sprintf("agent-groups/%v/agents/%v", groupID, agentID)), nil)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, nil)
+}
+
+func (io *TenableIO) RemoveAgentFromGroup(groupID int, agentID int) error {
+	return io.RemoveAgentFromGroupWithContext(context.Background(), groupID, agentID)
+}
+
+func (io *TenableIO) RemoveAgentFromGroupWithContext(ctx context.Context, groupID int, agentID int) (err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.RemoveAgentFromGroup",
+		"scanners/{id}/agent-groups/{id}/agents/{id}",
+		Attribute{Key: AttrAgentGroupID, Value: groupID}, Attribute{Key: AttrAgentID, Value: agentID})
+	defer func() { endSpan(span, err) }()
+
+	resp, err := io.DeleteWithContext(ctx, io.scannerPath(fmt.Sprintf("agent-groups/%v/agents/%v", groupID, agentID)), "")
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, nil)
+}
+
+func (io *TenableIO) AddAgentsToGroup(groupID int, agentIDs []int) (AgentBulkTask, error) {
+	return io.AddAgentsToGroupWithContext(context.Background(), groupID, agentIDs)
+}
+
+// AddAgentsToGroupWithContext starts a bulk operation that adds the given agents to a group. Follow it with
+// WaitForAgentTask.
+func (io *TenableIO) AddAgentsToGroupWithContext(ctx context.Context, groupID int, agentIDs []int) (task AgentBulkTask, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.AddAgentsToGroup",
+		"scanners/{id}/agent-groups/{id}/agents/_bulk/add", Attribute{Key: AttrAgentGroupID, Value: groupID})
+	defer func() { endSpan(span, err) }()
+
+	io.BaseClient.logger().Info("Adding agents to group", "group_id", groupID, "count", len(agentIDs))
+	return io.startAgentTask(ctx, io.scannerPath(fmt.Sprintf("agent-groups/%v/agents/_bulk/add", groupID)), agentIDs,
+		groupID)
+}
+
+func (io *TenableIO) RemoveAgentsFromGroup(groupID int, agentIDs []int) (AgentBulkTask, error) {
+	return io.RemoveAgentsFromGroupWithContext(context.Background(), groupID, agentIDs)
+}
+
+// RemoveAgentsFromGroupWithContext starts a bulk operation that removes the given agents from a group. Follow it with
+// WaitForAgentTask.
+func (io *TenableIO) RemoveAgentsFromGroupWithContext(ctx context.Context, groupID int, agentIDs []int) (task AgentBulkTask, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.RemoveAgentsFromGroup",
+		"scanners/{id}/agent-groups/{id}/agents/_bulk/remove", Attribute{Key: AttrAgentGroupID, Value: groupID})
+	defer func() { endSpan(span, err) }()
+
+	io.BaseClient.logger().Info("Removing agents from group", "group_id", groupID, "count", len(agentIDs))
+	return io.startAgentTask(ctx, io.scannerPath(fmt.Sprintf("agent-groups/%v/agents/_bulk/remove", groupID)),
+		agentIDs, groupID)
+}
+
+type AgentGroupListResponse struct {
+	Groups []AgentGroup `json:"groups"`
+}
+
+type AgentGroup struct {
+	ID               int    `json:"id"`
+	UUID             string `json:"uuid"`
+	Name             string `json:"name"`
+	Owner            string `json:"owner"`
+	OwnerID          int    `json:"owner_id"`
+	OwnerName        string `json:"owner_name"`
+	OwnerUUID        string `json:"owner_uuid"`
+	Shared           int    `json:"shared"`
+	UserPermissions  int    `json:"user_permissions"`
+	CreationDate     int    `json:"creation_date"`
+	LastModifiedDate int    `json:"last_modified_date"`
+	Timestamp        int    `json:"timestamp"`
+	AgentsCount      int    `json:"agents_count"`
+}
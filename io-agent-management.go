@@ -0,0 +1,164 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrAgentTaskFailed is returned by WaitForAgentTask when Tenable.io reports that a bulk agent operation failed.
+var ErrAgentTaskFailed = errors.New("agent bulk operation failed")
+
+// Statuses of a bulk agent operation.
+const (
+	AgentTaskNew       = "NEW"
+	AgentTaskRunning   = "RUNNING"
+	AgentTaskCompleted = "COMPLETED"
+	AgentTaskFailed    = "FAILED"
+)
+
+func (io *TenableIO) GetAgent(agentID int) (Agent, error) {
+	return io.GetAgentWithContext(context.Background(), agentID)
+}
+
+func (io *TenableIO) GetAgentWithContext(ctx context.Context, agentID int) (agent Agent, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.GetAgent", "scanners/{id}/agents/{id}",
+		Attribute{Key: AttrAgentID, Value: agentID})
+	defer func() { endSpan(span, err) }()
+
+	resp, err := io.GetWithContext(ctx, io.scannerPath(fmt.Sprintf("agents/%v", agentID)), "")
+	if err != nil {
+		return agent, err
+	}
+	err = decodeResponse(resp, &agent)
+	return agent, err
+}
+
+func (io *TenableIO) UnlinkAgent(agentID int) error {
+	return io.UnlinkAgentWithContext(context.Background(), agentID)
+}
+
+// UnlinkAgentWithContext unlinks an agent from Tenable.io. The agent stops reporting and is removed from its groups.
+func (io *TenableIO) UnlinkAgentWithContext(ctx context.Context, agentID int) (err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.UnlinkAgent", "scanners/{id}/agents/{id}",
+		Attribute{Key: AttrAgentID, Value: agentID})
+	defer func() { endSpan(span, err) }()
+
+	io.BaseClient.logger().Info("Unlinking agent", "agent_id", agentID)
+	resp, err := io.DeleteWithContext(ctx, io.scannerPath(fmt.Sprintf("agents/%v", agentID)), "")
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, nil)
+}
+
+func (io *TenableIO) UnlinkAgents(agentIDs []int) (AgentBulkTask, error) {
+	return io.UnlinkAgentsWithContext(context.Background(), agentIDs)
+}
+
+// UnlinkAgentsWithContext starts a bulk operation that unlinks the given agents. Follow it with WaitForAgentTask.
+func (io *TenableIO) UnlinkAgentsWithContext(ctx context.Context, agentIDs []int) (task AgentBulkTask, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.UnlinkAgents", "scanners/{id}/agents/_bulk/unlink")
+	defer func() { endSpan(span, err) }()
+
+	io.BaseClient.logger().Info("Unlinking agents", "count", len(agentIDs))
+	return io.startAgentTask(ctx, io.scannerPath("agents/_bulk/unlink"), agentIDs, 0)
+}
+
+func (io *TenableIO) AgentTaskStatus(task AgentBulkTask) (AgentBulkTask, error) {
+	return io.AgentTaskStatusWithContext(context.Background(), task)
+}
+
+// AgentTaskStatusWithContext fetches the current state of a bulk operation started by UnlinkAgents,
+// AddAgentsToGroup or RemoveAgentsFromGroup.
+func (io *TenableIO) AgentTaskStatusWithContext(ctx context.Context, task AgentBulkTask) (status AgentBulkTask, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.AgentTaskStatus", "scanners/{id}/agents/_bulk/{uuid}")
+	defer func() { endSpan(span, err) }()
+
+	endpoint := io.scannerPath(fmt.Sprintf("agents/_bulk/%v", task.TaskID))
+	if task.GroupID != 0 {
+		endpoint = io.scannerPath(fmt.Sprintf("agent-groups/%v/agents/_bulk/%v", task.GroupID, task.TaskID))
+	}
+	resp, err := io.GetWithContext(ctx, endpoint, "")
+	if err != nil {
+		return status, err
+	}
+	if err = decodeResponse(resp, &status); err != nil {
+		return status, err
+	}
+	status.GroupID = task.GroupID
+	return status, nil
+}
+
+func (io *TenableIO) WaitForAgentTask(task AgentBulkTask, pollInterval time.Duration) (AgentBulkTask, error) {
+	return io.WaitForAgentTaskWithContext(context.Background(), task, pollInterval)
+}
+
+// WaitForAgentTaskWithContext polls a bulk operation every pollInterval (5 seconds when 0) until it completes. A
+// failed operation is returned together with an error wrapping ErrAgentTaskFailed.
+func (io *TenableIO) WaitForAgentTaskWithContext(ctx context.Context, task AgentBulkTask, pollInterval time.Duration) (AgentBulkTask, error) {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	for {
+		switch task.Status {
+		case AgentTaskCompleted:
+			return task, nil
+		case AgentTaskFailed:
+			return task, fmt.Errorf("agent task %v: %v: %w", task.TaskID, task.Message, ErrAgentTaskFailed)
+		}
+		if task.Status != "" {
+			if err := sleepContext(ctx, pollInterval); err != nil {
+				return task, err
+			}
+		}
+		status, err := io.AgentTaskStatusWithContext(ctx, task)
+		if err != nil {
+			return task, err
+		}
+		io.BaseClient.logger().Debug("Fetched agent task status", "task_id", task.TaskID, "status", status.Status,
+			"completion_percentage", status.CompletionPercentage)
+		task = status
+	}
+}
+
+// startAgentTask posts a bulk agent operation. groupID is recorded in the task so its status is polled on the group.
+func (io *TenableIO) startAgentTask(ctx context.Context, endpoint string, agentIDs []int, groupID int) (AgentBulkTask, error) {
+	var task AgentBulkTask
+	body, err := json.Marshal(agentBulkRequest{Items: agentIDs})
+	if err != nil {
+		return task, err
+	}
+	resp, err := io.PostWithContext(ctx, endpoint, body)
+	if err != nil {
+		return task, err
+	}
+	if err = decodeResponse(resp, &task); err != nil {
+		return task, err
+	}
+	task.GroupID = groupID
+	return task, nil
+}
+
+type agentBulkRequest struct {
+	Items []int `json:"items"`
+}
+
+// AgentBulkTask is a bulk agent operation running on Tenable.io.
+type AgentBulkTask struct {
+	TaskID                  string     `json:"task_id"`
+	ContainerUUID           string     `json:"container_uuid"`
+	Status                  string     `json:"status"`
+	Message                 string     `json:"message"`
+	StartTime               UnixMillis `json:"start_time"`
+	EndTime                 UnixMillis `json:"end_time"`
+	LastUpdateTime          UnixMillis `json:"last_update_time"`
+	TotalWorkUnits          int        `json:"total_work_units"`
+	TotalWorkUnitsCompleted int        `json:"total_work_units_completed"`
+	CompletionPercentage    int        `json:"completion_percentage"`
+	// GroupID is the agent group of an AddAgentsToGroup or RemoveAgentsFromGroup operation, whose status is
+	// reported under the group.
+	GroupID int `json:"-"`
+}
@@ -1,20 +1,45 @@
 package go_tenable
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
 )
 
-func (io *TenableIO) ListAgents() []AgentResponse {
-	log.Printf("Fetching all agent information from Tenable.io\n")
-	var agentResponses []AgentResponse
-	const limit = 5000
+// WithScannerID sets the scanner the agent endpoints are called on, scanners/{id}/agents. Agents linked to
+// Tenable.io belong to scanner 1, which is the default.
+func WithScannerID(id int) Option {
+	return func(o *clientOptions) {
+		o.scannerID = id
+	}
+}
+
+// scannerPath returns the path of an endpoint below the configured scanner, e.g. "scanners/1/agents".
+func (io *TenableIO) scannerPath(endpoint string) string {
+	id := io.scannerID
+	if id == 0 {
+		id = 1
+	}
+	return fmt.Sprintf("scanners/%v/%v", id, endpoint)
+}
+
+func (io *TenableIO) ListAgents() ([]AgentResponse, error) {
+	return io.ListAgentsWithContext(context.Background())
+}
+
+func (io *TenableIO) ListAgentsWithContext(ctx context.Context) (agentResponses []AgentResponse, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.ListAgents", "scanners/{id}/agents")
+	defer func() { endSpan(span, err) }()
+
+	io.BaseClient.logger().Info("Fetching all agent information from Tenable.io")
+	const limit = agentPageSize
 	offset := 0
 	for {
 
-		agentRes := fetchAgentBatch(io, limit, offset)
+		agentRes, err := fetchAgentBatch(ctx, io, limit, offset)
+		if err != nil {
+			return agentResponses, err
+		}
 		if len(agentRes.Agents) > 0 {
 			agentResponses = append(agentResponses, agentRes)
 		}
@@ -24,47 +49,91 @@ func (io *TenableIO) ListAgents() []AgentResponse {
 		}
 		offset += limit
 	}
-	return agentResponses
+	return agentResponses, nil
 }
 
-func fetchAgentBatch(io *TenableIO, limit int, offset int) AgentResponse {
-	log.Printf("Fetching agents [%v - %v]\n", offset, offset+limit)
+// agentPageSize is the largest page Tenable.io will return from the agents endpoint.
+const agentPageSize = 5000
 
-	resp, err := io.Get("scanners/1/agents",
-		fmt.Sprintf("offset=%v&limit=%v", offset, offset+limit))
-	if err != nil {
-		log.Printf("Unable to fetch Agent batch: %v\n", err)
+// Agents returns an iterator over every agent linked to Tenable.io, fetching pageSize agents per request. A pageSize
+// of 0 uses the largest page the API allows.
+func (io *TenableIO) Agents(ctx context.Context, pageSize int) *AgentIterator {
+	if pageSize <= 0 || pageSize > agentPageSize {
+		pageSize = agentPageSize
 	}
-	tmp, _ := ioutil.ReadAll(resp.Body)
+	return &AgentIterator{newIterator(ctx, pageSize,
+		func(ctx context.Context, offset int, limit int, _ interface{}) ([]interface{}, int, error) {
+			ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.Agents", "scanners/{id}/agents")
+			batch, err := fetchAgentBatch(ctx, io, limit, offset)
+			endSpan(span, err)
+			if err != nil {
+				return nil, 0, err
+			}
+			items := make([]interface{}, len(batch.Agents))
+			for i, agent := range batch.Agents {
+				items[i] = agent
+			}
+			total := batch.Pagination.Total
+			if total == 0 {
+				total = -1
+			}
+			return items, total, nil
+		})}
+}
+
+// AgentIterator streams agents one at a time. See Iterator for usage.
+type AgentIterator struct {
+	*Iterator
+}
+
+// Value returns the agent Next advanced to.
+func (it *AgentIterator) Value() Agent {
+	agent, _ := it.Iterator.Value().(Agent)
+	return agent
+}
+
+// ForEach calls fn for every remaining agent, stopping at the first error.
+func (it *AgentIterator) ForEach(fn func(Agent) error) error {
+	return it.forEach(func(v interface{}) error {
+		return fn(v.(Agent))
+	})
+}
+
+func fetchAgentBatch(ctx context.Context, io *TenableIO, limit int, offset int) (AgentResponse, error) {
+	io.BaseClient.logger().Debug("Fetching agent batch", "offset", offset, "limit", limit)
+
 	var agentResponse AgentResponse
-	unmarshalError := json.Unmarshal(tmp, &agentResponse)
-	if unmarshalError != nil {
-		log.Printf("Error unmarshaling response: %v\n", unmarshalError)
+	resp, err := io.GetWithContext(ctx, io.scannerPath("agents"),
+		fmt.Sprintf("offset=%v&limit=%v", offset, limit))
+	if err != nil {
+		return agentResponse, err
 	}
 
-	return agentResponse
+	// Agents are decoded one at a time so the raw page is never held alongside the decoded one
+	err = streamResponse(resp, func(dec *json.Decoder) error {
+		return forEachField(dec, func(key string, dec *json.Decoder) error {
+			switch key {
+			case "agents":
+				return forEachElement(dec, func(dec *json.Decoder) error {
+					var agent Agent
+					if err := dec.Decode(&agent); err != nil {
+						return err
+					}
+					agentResponse.Agents = append(agentResponse.Agents, agent)
+					return nil
+				})
+			case "pagination":
+				return dec.Decode(&agentResponse.Pagination)
+			default:
+				return skipValue(dec)
+			}
+		})
+	})
+	return agentResponse, err
 }
 
 type AgentResponse struct {
-	Agents []struct {
-		ID           int    `json:"id"`
-		UUID         string `json:"uuid"`
-		Name         string `json:"name"`
-		Platform     string `json:"platform"`
-		Distro       string `json:"distro"`
-		IP           string `json:"ip"`
-		LastScanned  int    `json:"last_scanned"`
-		PluginFeedID string `json:"plugin_feed_id"`
-		CoreBuild    string `json:"core_build"`
-		CoreVersion  string `json:"core_version"`
-		LinkedOn     int    `json:"linked_on"`
-		LastConnect  int    `json:"last_connect"`
-		Status       string `json:"status"`
-		Groups       []struct {
-			Name string `json:"name"`
-			ID   int    `json:"id"`
-		} `json:"groups"`
-	} `json:"agents"`
+	Agents     []Agent `json:"agents"`
 	Pagination struct {
 		Total  int `json:"total"`
 		Limit  int `json:"limit"`
@@ -75,3 +144,23 @@ type AgentResponse struct {
 		} `json:"sort"`
 	} `json:"pagination"`
 }
+
+type Agent struct {
+	ID           int    `json:"id"`
+	UUID         string `json:"uuid"`
+	Name         string `json:"name"`
+	Platform     string `json:"platform"`
+	Distro       string `json:"distro"`
+	IP           string `json:"ip"`
+	LastScanned  int    `json:"last_scanned"`
+	PluginFeedID string `json:"plugin_feed_id"`
+	CoreBuild    string `json:"core_build"`
+	CoreVersion  string `json:"core_version"`
+	LinkedOn     int    `json:"linked_on"`
+	LastConnect  int    `json:"last_connect"`
+	Status       string `json:"status"`
+	Groups       []struct {
+		Name string `json:"name"`
+		ID   int    `json:"id"`
+	} `json:"groups"`
+}
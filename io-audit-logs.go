@@ -1,38 +1,156 @@
 package go_tenable
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
+	"net/url"
+	"strconv"
 	"time"
 )
 
 func (io *TenableIO) ListEvents(filter EventFilter) ([]Event, error) {
+	return io.ListEventsWithContext(context.Background(), filter)
+}
+
+// ListEventsWithContext returns every audit log event matching filter, following the pages of the result set. Use
+// Events to process large result sets without holding them in memory.
+func (io *TenableIO) ListEventsWithContext(ctx context.Context, filter EventFilter) (events []Event, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.ListEvents", "audit-log/v1/events")
+	defer func() { endSpan(span, err) }()
+
+	err = io.Events(ctx, filter, 0).ForEach(func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// eventPageSize is the largest page Tenable.io will return from the audit log endpoint.
+const eventPageSize = 5000
 
-	var GetParams string
+// eventTimeResolution is the precision of the times the audit log reports and filters on.
+const eventTimeResolution = time.Millisecond
+
+// Events returns an iterator over the audit log events matching filter, fetching pageSize events per request. A
+// pageSize of 0 uses the largest page the API allows. The audit log returns events newest first and has no offset
+// parameter, so each page after the first is requested with a "date.lt" filter just past the time of the oldest
+// event received, and the events in that window that were already returned are dropped. Paging stops when a page
+// returns no event that has not been returned before, and fails if the events are not returned newest first or if
+// more events share one timestamp than a single request can return.
+func (io *TenableIO) Events(ctx context.Context, filter EventFilter, pageSize int) *EventIterator {
+	if pageSize <= 0 || pageSize > eventPageSize {
+		pageSize = eventPageSize
+	}
+	// before is the date.lt bound of the next request and seen holds the times of the events returned at or after
+	// the oldest time returned so far, which the next request returns again.
+	var before time.Time
+	seen := make(map[string]time.Time)
+	return &EventIterator{newIterator(ctx, pageSize,
+		func(ctx context.Context, _ int, limit int, _ interface{}) ([]interface{}, int, error) {
+			var items []interface{}
+			for {
+				n := limit + len(seen)
+				if n > eventPageSize {
+					n = eventPageSize
+				}
+				events, err := io.fetchEventPage(ctx, filter, n, before)
+				if err != nil {
+					return nil, 0, err
+				}
+				added := 0
+				for i, event := range events {
+					if i > 0 && event.Received.After(events[i-1].Received) {
+						return nil, 0, fmt.Errorf("audit log events were not returned newest first: %v follows %v",
+							event.Received.Format(time.RFC3339Nano), events[i-1].Received.Format(time.RFC3339Nano))
+					}
+					// Events at or after the bound were returned by an earlier page; they only come back when the
+					// filter was not applied.
+					if _, ok := seen[event.ID]; ok || (!before.IsZero() && !event.Received.Before(before)) {
+						continue
+					}
+					seen[event.ID] = event.Received
+					items = append(items, event)
+					added++
+				}
+				if added > 0 {
+					oldest := items[len(items)-1].(Event).Received
+					before = oldest.Add(eventTimeResolution)
+					for id, received := range seen {
+						if !received.Before(before) {
+							delete(seen, id)
+						}
+					}
+				}
+				if len(events) < n || len(items) >= limit {
+					return items, -1, nil
+				}
+				if added == 0 {
+					if len(seen) >= eventPageSize {
+						return nil, 0, fmt.Errorf("more than %v audit log events were received at %v", eventPageSize,
+							before.Add(-eventTimeResolution).Format(time.RFC3339Nano))
+					}
+					return items, -1, nil
+				}
+			}
+		})}
+}
+
+// fetchEventPage requests up to limit events, those received before the given time unless it is zero.
+func (io *TenableIO) fetchEventPage(ctx context.Context, filter EventFilter, limit int, before time.Time) (events []Event, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.Events", "audit-log/v1/events")
+	defer func() { endSpan(span, err) }()
+
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
 	if filter != (EventFilter{}) {
-		filterString := fmt.Sprintf("%v.%v:%v", filter.Filter, filter.Operator, filter.Value)
-		GetParams = fmt.Sprintf("f=%v", filterString)
-	} else {
-		GetParams = ""
+		params.Add("f", fmt.Sprintf("%v.%v:%v", filter.Filter, filter.Operator, filter.Value))
 	}
-	resp, err := io.Get("audit-log/v1/events", GetParams)
+	if !before.IsZero() {
+		params.Add("f", "date.lt:"+before.UTC().Format("2006-01-02T15:04:05.000Z"))
+	}
+
+	resp, err := io.GetWithContext(ctx, "audit-log/v1/events", params.Encode())
 	if err != nil {
-		log.Printf("Unable to request audit log events: %v\n", err)
 		return nil, err
 	}
+	err = streamResponse(resp, func(dec *json.Decoder) error {
+		return forEachField(dec, func(key string, dec *json.Decoder) error {
+			if key != "events" {
+				return skipValue(dec)
+			}
+			return forEachElement(dec, func(dec *json.Decoder) error {
+				var event Event
+				if err := dec.Decode(&event); err != nil {
+					return err
+				}
+				events = append(events, event)
+				return nil
+			})
+		})
+	})
+	return events, err
+}
 
-	ResponseBytes, _ := ioutil.ReadAll(resp.Body)
-	var Logs AuditLogResponse
+// EventIterator streams audit log events one at a time. See Iterator for usage.
+type EventIterator struct {
+	*Iterator
+}
 
-	// Unmarshal API response to AuditLogResponse struct
-	responseError := json.Unmarshal(ResponseBytes, &Logs)
-	if responseError != nil {
-		log.Printf("Unable to unmarshal Audit Log Response: %v\n", responseError)
-	}
+// Value returns the event Next advanced to.
+func (it *EventIterator) Value() Event {
+	event, _ := it.Iterator.Value().(Event)
+	return event
+}
 
-	return Logs.Events, nil
+// ForEach calls fn for every remaining event, stopping at the first error.
+func (it *EventIterator) ForEach(fn func(Event) error) error {
+	return it.forEach(func(v interface{}) error {
+		return fn(v.(Event))
+	})
 }
 
 type EventFilter struct {
@@ -0,0 +1,128 @@
+package go_tenable_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+func TestEventsPagesThroughSharedTimestamps(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	now := time.Now().UTC().Truncate(time.Second)
+	f.IOEvents = nil
+	// Events within a millisecond of each other share a timestamp as far as the date filters are concerned.
+	for i, age := range []time.Duration{
+		0,
+		time.Minute - 300*time.Microsecond,
+		time.Minute, time.Minute, time.Minute,
+		2 * time.Minute,
+		3 * time.Minute, 3 * time.Minute,
+	} {
+		f.IOEvents = append(f.IOEvents, go_tenable.Event{ID: strconv.Itoa(i), Received: now.Add(-age)})
+	}
+	srv := tenabletest.NewIOServer(f)
+	defer srv.Close()
+	tio, err := go_tenable.NewTenableIO(f.IOAccessKey, f.IOSecretKey, srv.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pageSize := range []int{1, 2, 3, 8, 0} {
+		var ids []string
+		err := tio.Events(context.Background(), go_tenable.EventFilter{}, pageSize).ForEach(func(event go_tenable.Event) error {
+			ids = append(ids, event.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("page size %v: %v", pageSize, err)
+		}
+		if len(ids) != len(f.IOEvents) {
+			t.Fatalf("page size %v: got events %v, want %v", pageSize, ids, len(f.IOEvents))
+		}
+		for i, id := range ids {
+			if id != strconv.Itoa(i) {
+				t.Fatalf("page size %v: got events %v out of order", pageSize, ids)
+			}
+		}
+	}
+
+	// The fake rejects the filters the audit log does not document, as the real API does.
+	_, err = tio.ListEvents(go_tenable.EventFilter{Filter: "date", Operator: "lte", Value: now.Format(time.RFC3339)})
+	if err == nil {
+		t.Fatal("the date.lte filter was accepted")
+	}
+}
+
+// eventServer serves events from a handler that ignores every filter, returning the first limit events of order.
+func eventServer(t *testing.T, order []go_tenable.Event) (go_tenable.TenableIO, *[]string, func()) {
+	t.Helper()
+	var filters []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query()["f"]...)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit > len(order) {
+			limit = len(order)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(go_tenable.AuditLogResponse{Events: order[:limit]})
+	}))
+	tio, err := go_tenable.NewTenableIO("access", "secret", go_tenable.WithBaseURL(srv.URL),
+		go_tenable.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tio, &filters, srv.Close
+}
+
+func TestEventsStopsWhenTheDateFilterIsIgnored(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	var events []go_tenable.Event
+	for i := 0; i < 5; i++ {
+		events = append(events, go_tenable.Event{ID: strconv.Itoa(i), Received: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	tio, filters, stop := eventServer(t, events)
+	defer stop()
+
+	var ids []string
+	err := tio.Events(context.Background(), go_tenable.EventFilter{}, 2).ForEach(func(event go_tenable.Event) error {
+		ids = append(ids, event.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without the filter every request returns the newest events again, so paging ends after the few older events
+	// the larger follow-up request happens to reach, without returning any event twice.
+	for i, id := range ids {
+		if id != strconv.Itoa(i) {
+			t.Fatalf("got events %v, want a prefix of the log without repeats", ids)
+		}
+	}
+	for _, f := range *filters {
+		if !strings.HasPrefix(f, "date.lt:") {
+			t.Errorf("sent undocumented filter %v", f)
+		}
+	}
+}
+
+func TestEventsRejectsOldestFirstPages(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	events := []go_tenable.Event{
+		{ID: "0", Received: now.Add(-time.Minute)},
+		{ID: "1", Received: now},
+	}
+	tio, _, stop := eventServer(t, events)
+	defer stop()
+
+	if _, err := tio.ListEvents(go_tenable.EventFilter{}); err == nil || !strings.Contains(err.Error(), "newest first") {
+		t.Fatalf("got error %v, want the ordering to be rejected", err)
+	}
+}
@@ -0,0 +1,157 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+func (export *Export) DownloadComplianceChunk(ChunkID int) ([]ComplianceFinding, error) {
+	return export.DownloadComplianceChunkWithContext(context.Background(), ChunkID)
+}
+
+// DownloadComplianceChunkWithContext downloads a chunk of a compliance export created with NewExport("compliance").
+func (export *Export) DownloadComplianceChunkWithContext(ctx context.Context, ChunkID int) (findings []ComplianceFinding, err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.DownloadComplianceChunk",
+		export.ExportType+"/export/{uuid}/chunks/{id}",
+		append(export.spanAttributes(), Attribute{Key: AttrChunkID, Value: ChunkID})...)
+	defer func() { endSpan(span, err) }()
+
+	if err = export.requireType(ExportTypeCompliance); err != nil {
+		return nil, err
+	}
+	findings = []ComplianceFinding{}
+	err = export.streamChunk(ctx, ChunkID, func(dec *json.Decoder) error {
+		var finding ComplianceFinding
+		if err := dec.Decode(&finding); err != nil {
+			return err
+		}
+		findings = append(findings, finding)
+		return nil
+	})
+	return findings, err
+}
+
+func (export *Export) StreamComplianceChunk(ChunkID int, fn func(ComplianceFinding) error) error {
+	return export.StreamComplianceChunkWithContext(context.Background(), ChunkID, fn)
+}
+
+// StreamComplianceChunkWithContext downloads a compliance export chunk and calls fn for each finding as it is
+// decoded. Iteration stops at the first error returned by fn, which is returned as is.
+func (export *Export) StreamComplianceChunkWithContext(ctx context.Context, ChunkID int, fn func(ComplianceFinding) error) (err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.StreamComplianceChunk",
+		export.ExportType+"/export/{uuid}/chunks/{id}",
+		append(export.spanAttributes(), Attribute{Key: AttrChunkID, Value: ChunkID})...)
+	defer func() { endSpan(span, err) }()
+
+	if err = export.requireType(ExportTypeCompliance); err != nil {
+		return err
+	}
+	return export.streamChunk(ctx, ChunkID, func(dec *json.Decoder) error {
+		var finding ComplianceFinding
+		if err := dec.Decode(&finding); err != nil {
+			return err
+		}
+		if err := fn(finding); err != nil {
+			return callbackError{err}
+		}
+		return nil
+	})
+}
+
+// ComplianceExportRequest is the body of a compliance export request. Zero values are left out of the request, so
+// only the filters that are set apply.
+type ComplianceExportRequest struct {
+	// NumFindings is the number of findings in each chunk (50 to 10000, default 5000).
+	NumFindings int
+	// Assets limits the export to the assets with the given UUIDs.
+	Assets []string
+
+	LastSeen  time.Time
+	FirstSeen time.Time
+	// State accepts "OPEN", "REOPENED" and "FIXED".
+	State []string
+	// ComplianceResults accepts "PASSED", "FAILED", "WARNING", "SKIPPED", "UNKNOWN" and "ERROR".
+	ComplianceResults []string
+}
+
+// MarshalJSON renders the request in the shape the compliance/export endpoint expects, with times as Unix
+// timestamps.
+func (req ComplianceExportRequest) MarshalJSON() ([]byte, error) {
+	filters := make(map[string]interface{})
+	if !req.LastSeen.IsZero() {
+		filters["last_seen"] = req.LastSeen.Unix()
+	}
+	if !req.FirstSeen.IsZero() {
+		filters["first_seen"] = req.FirstSeen.Unix()
+	}
+	if len(req.State) > 0 {
+		filters["state"] = req.State
+	}
+	if len(req.ComplianceResults) > 0 {
+		filters["compliance_results"] = req.ComplianceResults
+	}
+
+	body := struct {
+		NumFindings int                    `json:"num_findings,omitempty"`
+		Assets      []string               `json:"asset,omitempty"`
+		Filters     map[string]interface{} `json:"filters,omitempty"`
+	}{req.NumFindings, req.Assets, filters}
+	return json.Marshal(body)
+}
+
+func (req ComplianceExportRequest) ToBytes() []byte {
+	ret, _ := json.Marshal(req)
+	return ret
+}
+
+// ComplianceFinding is the result of one audit check on one asset from a compliance export chunk.
+type ComplianceFinding struct {
+	AssetUUID        string                `json:"asset_uuid"`
+	Asset            ComplianceAsset       `json:"asset"`
+	PluginID         int                   `json:"plugin_id"`
+	AuditFile        string                `json:"audit_file"`
+	CheckID          string                `json:"check_id"`
+	CheckName        string                `json:"check_name"`
+	CheckInfo        string                `json:"check_info"`
+	Description      string                `json:"description"`
+	Synopsis         string                `json:"synopsis"`
+	Solution         string                `json:"solution"`
+	SeeAlso          string                `json:"see_also"`
+	ExpectedValue    string                `json:"expected_value"`
+	ActualValue      string                `json:"actual_value"`
+	Status           string                `json:"status"`
+	State            string                `json:"state"`
+	Reference        []ComplianceReference `json:"reference"`
+	BenchmarkName    string                `json:"compliance_benchmark_name"`
+	BenchmarkVersion string                `json:"compliance_benchmark_version"`
+	ControlID        string                `json:"compliance_control_id"`
+	FullID           string                `json:"compliance_full_id"`
+	FunctionalID     string                `json:"compliance_functional_id"`
+	InformationalID  string                `json:"compliance_informational_id"`
+	UnameOutput      string                `json:"uname_output"`
+	FirstSeen        time.Time             `json:"first_seen"`
+	LastSeen         time.Time             `json:"last_seen"`
+	LastObserved     time.Time             `json:"last_observed"`
+	LastFixed        time.Time             `json:"last_fixed"`
+	IndexedAt        time.Time             `json:"indexed_at"`
+}
+
+// ComplianceReference maps a check to a control of a compliance framework, e.g. {"800-53", "AC-2"}.
+type ComplianceReference struct {
+	Framework string `json:"framework"`
+	Control   string `json:"control"`
+}
+
+type ComplianceAsset struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	AgentUUID        string   `json:"agent_uuid"`
+	NetworkID        string   `json:"network_id"`
+	SystemType       string   `json:"system_type"`
+	IPv4Addresses    []string `json:"ipv4_addresses"`
+	IPv6Addresses    []string `json:"ipv6_addresses"`
+	FQDNs            []string `json:"fqdns"`
+	MacAddresses     []string `json:"mac_addresses"`
+	OperatingSystems []string `json:"operating_systems"`
+}
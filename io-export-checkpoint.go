@@ -0,0 +1,136 @@
+package go_tenable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrCheckpointNotFound is returned by CheckpointStore.Load when no state has been saved under the key.
+var ErrCheckpointNotFound = errors.New("export checkpoint not found")
+
+// ExportState is the serializable state of an Export, saved by Export.Run when ExportRunOptions.Checkpoint is set and
+// restored with TenableIO.ResumeExport.
+type ExportState struct {
+	ExportUUID      string          `json:"export_uuid"`
+	ExportType      string          `json:"export_type"`
+	ExportStatus    string          `json:"export_status,omitempty"`
+	AvailableChunks []int           `json:"available_chunks"`
+	ProcessedChunks []int           `json:"processed_chunks"`
+	Filters         json.RawMessage `json:"filters,omitempty"`
+	RequestedAt     time.Time       `json:"requested_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// State returns a copy of the export's state, with UpdatedAt set to the current time.
+func (export *Export) State() ExportState {
+	return ExportState{
+		ExportUUID:      export.ExportUUID,
+		ExportType:      export.ExportType,
+		ExportStatus:    export.ExportStatus,
+		AvailableChunks: append([]int(nil), export.AvailableChunks...),
+		ProcessedChunks: append([]int(nil), export.ProcessedChunks...),
+		Filters:         append(json.RawMessage(nil), export.Filters...),
+		RequestedAt:     export.RequestedAt,
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// ResumeExport reattaches to an export that was requested earlier, typically by a process that did not get to finish
+// it. Export.Run skips the chunks in state.ProcessedChunks, so only the remaining chunks are downloaded. A bare
+// ExportState{ExportUUID: uuid, ExportType: ExportTypeVulns} is enough to reattach to an export by UUID.
+//
+// Records of a chunk that was being processed when the previous run stopped are delivered again, since a chunk is
+// only marked processed once all of its records have been delivered. Tenable.io expires exports after a few days,
+// after which the status request fails with a 404 and the export must be requested again.
+func (io TenableIO) ResumeExport(state ExportState) Export {
+	ret := io.NewExport(state.ExportType)
+	ret.ExportUUID = state.ExportUUID
+	ret.ExportStatus = state.ExportStatus
+	ret.AvailableChunks = append([]int(nil), state.AvailableChunks...)
+	ret.ProcessedChunks = append([]int(nil), state.ProcessedChunks...)
+	ret.Filters = append(json.RawMessage(nil), state.Filters...)
+	ret.RequestedAt = state.RequestedAt
+	return ret
+}
+
+// ResumeExportService is ResumeExport behind the IOExportService interface.
+func (io TenableIO) ResumeExportService(state ExportState) IOExportService {
+	export := io.ResumeExport(state)
+	return &export
+}
+
+// CheckpointStore persists export state between runs. Save is called with the run's lock held, so implementations
+// should return promptly; they need not be safe for concurrent use by a single run.
+type CheckpointStore interface {
+	// Load returns the state saved under key, or ErrCheckpointNotFound.
+	Load(key string) (ExportState, error)
+	Save(key string, state ExportState) error
+	Delete(key string) error
+}
+
+// FileCheckpointStore saves each checkpoint as a JSON file in Dir. Files are replaced atomically, so a crash while
+// saving leaves the previous checkpoint intact.
+type FileCheckpointStore struct {
+	Dir string
+}
+
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create checkpoint directory: %w", err)
+	}
+	return &FileCheckpointStore{Dir: dir}, nil
+}
+
+func (s *FileCheckpointStore) Load(key string) (ExportState, error) {
+	var state ExportState
+	data, err := ioutil.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return state, ErrCheckpointNotFound
+	}
+	if err != nil {
+		return state, err
+	}
+	if err = json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("unable to decode checkpoint %v: %w", key, err)
+	}
+	return state, nil
+}
+
+func (s *FileCheckpointStore) Save(key string, state ExportState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(s.Dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Delete removes the checkpoint saved under key. Deleting a missing checkpoint is not an error.
+func (s *FileCheckpointStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileCheckpointStore) path(key string) string {
+	return filepath.Join(s.Dir, url.PathEscape(key)+".json")
+}
@@ -0,0 +1,118 @@
+package go_tenable_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+// chunkCounter records the export chunks downloaded through it.
+type chunkCounter struct {
+	next http.RoundTripper
+
+	mu     sync.Mutex
+	chunks []string
+}
+
+func (c *chunkCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if i := strings.Index(req.URL.Path, "/chunks/"); i >= 0 {
+		c.mu.Lock()
+		c.chunks = append(c.chunks, req.URL.Path[i+len("/chunks/"):])
+		c.mu.Unlock()
+	}
+	return c.next.RoundTrip(req)
+}
+
+func (c *chunkCounter) downloaded() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := append([]string(nil), c.chunks...)
+	sort.Strings(ret)
+	c.chunks = nil
+	return ret
+}
+
+func TestResumeExportDownloadsOnlyTheRemainingChunks(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	f.IOAssets = make(go_tenable.AssetChunkDownloadResponse, 250)
+	for i := range f.IOAssets {
+		f.IOAssets[i].ID = "asset-" + strconv.Itoa(i)
+	}
+	srv := tenabletest.NewIOServer(f)
+	defer srv.Close()
+	counter := &chunkCounter{next: srv.Client().Transport}
+	tio, err := go_tenable.NewTenableIO(f.IOAccessKey, f.IOSecretKey, go_tenable.WithBaseURL(srv.URL),
+		go_tenable.WithRoundTripper(counter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := go_tenable.NewFileCheckpointStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := go_tenable.ExportRunOptions{Workers: 1, PollInterval: time.Millisecond, Checkpoint: store,
+		CheckpointKey: "nightly-assets"}
+
+	if _, err = store.Load("nightly-assets"); !errors.Is(err, go_tenable.ErrCheckpointNotFound) {
+		t.Fatalf("Load of a missing key: got %v, want ErrCheckpointNotFound", err)
+	}
+
+	export := tio.NewExport(go_tenable.ExportTypeAssets)
+	payload, err := go_tenable.AssetExportRequest{ChunkSize: 100}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = export.RequestExport(payload); err != nil {
+		t.Fatal(err)
+	}
+	stop := errors.New("stop")
+	err = export.Run(opts, func(record go_tenable.ExportRecord) error {
+		if record.ChunkID > 1 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("got error %v, want the callback's error", err)
+	}
+	counter.downloaded()
+
+	state, err := store.Load("nightly-assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ExportUUID != export.ExportUUID || len(state.ProcessedChunks) != 1 || state.ProcessedChunks[0] != 1 {
+		t.Fatalf("checkpoint holds %+v, want chunk 1 of %v processed", state, export.ExportUUID)
+	}
+
+	resumed := tio.ResumeExport(state)
+	records := 0
+	err = resumed.Run(opts, func(record go_tenable.ExportRecord) error {
+		records++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := counter.downloaded(); strings.Join(got, ",") != "2,3" {
+		t.Errorf("resumed run downloaded chunks %v, want 2 and 3", got)
+	}
+	if records != 150 {
+		t.Errorf("resumed run delivered %v records, want 150", records)
+	}
+}
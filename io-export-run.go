@@ -0,0 +1,273 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrExportFailed is returned by Export.Run and Export.Stream when Tenable.io reports that the export ended with the
+// ERROR or CANCELLED status.
+var ErrExportFailed = errors.New("export did not finish")
+
+// ExportRunOptions configures Export.Run and Export.Stream. The zero value uses the defaults.
+type ExportRunOptions struct {
+	// Workers is the number of chunks downloaded at once. Defaults to 4.
+	Workers int
+	// PollInterval is the time between status requests until the export is FINISHED. Defaults to 5 seconds.
+	PollInterval time.Duration
+	// Buffer is the number of records Stream holds for the reader before the workers block. Defaults to 0, so every
+	// record is handed over directly.
+	Buffer int
+	// Checkpoint, when set, receives the export state after every status poll and every processed chunk, so a run
+	// that is interrupted can be continued with TenableIO.ResumeExport.
+	Checkpoint CheckpointStore
+	// CheckpointKey is the key the state is saved under. Defaults to the export UUID.
+	CheckpointKey string
+}
+
+func (opts ExportRunOptions) withDefaults() ExportRunOptions {
+	if opts.Workers < 1 {
+		opts.Workers = 4
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.Buffer < 0 {
+		opts.Buffer = 0
+	}
+	return opts
+}
+
+// ExportRecord is a single record delivered by Export.Run and Export.Stream. The field matching the export type is
+// set and the others are nil.
+type ExportRecord struct {
+	ChunkID    int
+	Asset      *ExportedAsset
+	Vuln       *VulnerabilityFinding
+	Compliance *ComplianceFinding
+}
+
+func (export *Export) Run(opts ExportRunOptions, fn func(ExportRecord) error) error {
+	return export.RunWithContext(context.Background(), opts, fn)
+}
+
+// RunWithContext processes an export requested with RequestExport until every chunk has been delivered. It polls the
+// export status, downloading each chunk as soon as Tenable.io makes it available rather than waiting for the export
+// to finish, and calls fn for every record. Chunks are appended to ProcessedChunks once all of their records have been
+// delivered, and chunks already in ProcessedChunks are skipped.
+//
+// fn is called from the worker goroutines, concurrently when Workers is above 1, and a slow fn holds back the
+// downloads. The first error returned by fn or met while downloading stops the other workers and is returned.
+func (export *Export) RunWithContext(ctx context.Context, opts ExportRunOptions, fn func(ExportRecord) error) (err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.Run", export.ExportType+"/export/{uuid}",
+		export.spanAttributes()...)
+	defer func() { endSpan(span, err) }()
+
+	decode, err := export.recordDecoder()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	run := &exportRun{
+		export: export,
+		opts:   opts.withDefaults(),
+		decode: decode,
+		fn:     fn,
+		cancel: cancel,
+	}
+	chunks := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < run.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run.work(ctx, chunks)
+		}()
+	}
+
+	pollErr := run.dispatch(ctx, chunks)
+	close(chunks)
+	wg.Wait()
+	if run.err != nil {
+		return run.err
+	}
+	return pollErr
+}
+
+func (export *Export) Stream(opts ExportRunOptions) (<-chan ExportRecord, <-chan error) {
+	return export.StreamWithContext(context.Background(), opts)
+}
+
+// StreamWithContext runs the export like RunWithContext and sends the records on the returned channel, which is
+// closed once the export is done. The error channel then receives the outcome, nil on success, and is closed. Workers
+// block while the channel is full, so a reader that stops early must cancel ctx to release them.
+func (export *Export) StreamWithContext(ctx context.Context, opts ExportRunOptions) (<-chan ExportRecord, <-chan error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	opts = opts.withDefaults()
+	records := make(chan ExportRecord, opts.Buffer)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		err := export.RunWithContext(ctx, opts, func(record ExportRecord) error {
+			select {
+			case records <- record:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		close(records)
+		errc <- err
+	}()
+	return records, errc
+}
+
+// exportRun is the state shared by the poller and the workers of a single Run. mu guards the Export fields the run
+// updates, so checkpoints are consistent snapshots.
+type exportRun struct {
+	export *Export
+	opts   ExportRunOptions
+	decode recordDecoder
+	fn     func(ExportRecord) error
+	cancel context.CancelFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+// dispatch polls the export status and sends each newly available chunk to the workers until the export is
+// FINISHED.
+func (run *exportRun) dispatch(ctx context.Context, chunks chan<- int) error {
+	export := run.export
+	run.mu.Lock()
+	dispatched := make(map[int]bool, len(export.ProcessedChunks))
+	for _, chunkID := range export.ProcessedChunks {
+		dispatched[chunkID] = true
+	}
+	run.mu.Unlock()
+
+	for {
+		statusRes, err := export.fetchStatus(ctx)
+		if err != nil {
+			return err
+		}
+		run.mu.Lock()
+		export.ExportStatus = statusRes.Status
+		export.AvailableChunks = statusRes.ChunksAvailable
+		err = run.checkpoint()
+		run.mu.Unlock()
+		if err != nil {
+			return err
+		}
+
+		for _, chunkID := range statusRes.ChunksAvailable {
+			if dispatched[chunkID] {
+				continue
+			}
+			dispatched[chunkID] = true
+			select {
+			case chunks <- chunkID:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		switch statusRes.Status {
+		case ExportStatusFinished:
+			return nil
+		case ExportStatusError, ExportStatusCancelled:
+			return fmt.Errorf("export %v ended with status %v: %w", export.ExportUUID, statusRes.Status,
+				ErrExportFailed)
+		}
+		if err = sleepContext(ctx, run.opts.PollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// work downloads chunks until chunks is closed or a chunk fails. The first failure is kept and cancels the run.
+func (run *exportRun) work(ctx context.Context, chunks <-chan int) {
+	for chunkID := range chunks {
+		err := run.export.runChunk(ctx, chunkID, run.decode, run.fn)
+		run.mu.Lock()
+		if err == nil {
+			run.export.ProcessedChunks = append(run.export.ProcessedChunks, chunkID)
+			err = run.checkpoint()
+		}
+		if err != nil && run.err == nil {
+			run.err = err
+		}
+		run.mu.Unlock()
+		if err != nil {
+			run.cancel()
+			return
+		}
+	}
+}
+
+// checkpoint saves the export state to the configured store. run.mu must be held.
+func (run *exportRun) checkpoint() error {
+	if run.opts.Checkpoint == nil {
+		return nil
+	}
+	key := run.opts.CheckpointKey
+	if key == "" {
+		key = run.export.ExportUUID
+	}
+	if err := run.opts.Checkpoint.Save(key, run.export.State()); err != nil {
+		return fmt.Errorf("saving export checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (export *Export) runChunk(ctx context.Context, chunkID int, decode recordDecoder, fn func(ExportRecord) error) (err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.RunChunk",
+		export.ExportType+"/export/{uuid}/chunks/{id}",
+		append(export.spanAttributes(), Attribute{Key: AttrChunkID, Value: chunkID})...)
+	defer func() { endSpan(span, err) }()
+
+	export.tioClient.BaseClient.logger().Debug("Downloading export chunk", "export_uuid", export.ExportUUID,
+		"chunk_id", chunkID)
+	return export.streamChunk(ctx, chunkID, func(dec *json.Decoder) error {
+		record := ExportRecord{ChunkID: chunkID}
+		if err := decode(dec, &record); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return callbackError{err}
+		}
+		return nil
+	})
+}
+
+// recordDecoder decodes the next chunk element into the ExportRecord field for the export type.
+type recordDecoder func(dec *json.Decoder, record *ExportRecord) error
+
+func (export *Export) recordDecoder() (recordDecoder, error) {
+	switch export.ExportType {
+	case ExportTypeAssets:
+		return func(dec *json.Decoder, record *ExportRecord) error {
+			record.Asset = &ExportedAsset{}
+			return dec.Decode(record.Asset)
+		}, nil
+	case ExportTypeVulns:
+		return func(dec *json.Decoder, record *ExportRecord) error {
+			record.Vuln = &VulnerabilityFinding{}
+			return dec.Decode(record.Vuln)
+		}, nil
+	case ExportTypeCompliance:
+		return func(dec *json.Decoder, record *ExportRecord) error {
+			record.Compliance = &ComplianceFinding{}
+			return dec.Decode(record.Compliance)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported export type %q", export.ExportType)
+}
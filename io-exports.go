@@ -1,18 +1,22 @@
 package go_tenable
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"time"
 )
 
 func (export *Export) GetUnprocessedChunks() []int {
 	var UnprocessedChunks []int
-	log.Printf("Chunks Available: %v ; Chunks Processed: %v\n", export.AvailableChunks, export.ProcessedChunks)
+	export.tioClient.BaseClient.logger().Debug("Checking for unprocessed chunks", "export_uuid", export.ExportUUID,
+		"available", export.AvailableChunks, "processed", export.ProcessedChunks)
+	processed := make(map[int]bool, len(export.ProcessedChunks))
+	for _, chunkId := range export.ProcessedChunks {
+		processed[chunkId] = true
+	}
 	for _, chunkId := range export.AvailableChunks {
-		if !intInSlice(chunkId, export.ProcessedChunks) {
+		if !processed[chunkId] {
 			UnprocessedChunks = append(UnprocessedChunks, chunkId)
 		}
 	}
@@ -28,48 +32,192 @@ func intInSlice(a int, list []int) bool {
 	return false
 }
 
-func (export *Export) RequestExport(Payload []byte) string {
-	resp, err := export.tioClient.Post(fmt.Sprintf("%v/export", export.ExportType), Payload)
-	tmp, _ := ioutil.ReadAll(resp.Body)
+func (export *Export) RequestExport(Payload []byte) (string, error) {
+	return export.RequestExportWithContext(context.Background(), Payload)
+}
+
+func (export *Export) RequestExportWithContext(ctx context.Context, Payload []byte) (exportUUID string, err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.RequestExport", export.ExportType+"/export",
+		Attribute{Key: AttrExportType, Value: export.ExportType})
+	defer func() {
+		span.SetAttributes(Attribute{Key: AttrExportUUID, Value: exportUUID})
+		endSpan(span, err)
+	}()
 
-	var exportRequestRes ExportRequestResponse
-	err = json.Unmarshal(tmp, &exportRequestRes)
+	resp, err := export.tioClient.PostWithContext(ctx, fmt.Sprintf("%v/export", export.ExportType), Payload)
 	if err != nil {
-		log.Printf("Unable to read response from %v export: %v\n", export.ExportType, err)
-		return ""
+		return "", err
+	}
+
+	var exportRequestRes ExportRequestResponse
+	if err = decodeResponse(resp, &exportRequestRes); err != nil {
+		return "", err
 	}
-	log.Printf("Export request response [%v]: %v \n", resp.StatusCode, exportRequestRes)
+	export.tioClient.BaseClient.logger().Info("Requested export", "type", export.ExportType,
+		"export_uuid", exportRequestRes.ExportUUID)
 	export.ExportUUID = exportRequestRes.ExportUUID
-	return exportRequestRes.ExportUUID
+	export.Filters = append(json.RawMessage(nil), Payload...)
+	export.RequestedAt = time.Now()
+	return exportRequestRes.ExportUUID, nil
 }
 
-func (export *Export) RequestStatus() string {
-	resp, err := export.tioClient.Get(fmt.Sprintf("%v/export/%v/status", export.ExportType, export.ExportUUID),
-		"")
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	var statusRes = ExportStatusResponse{}
-	err = json.Unmarshal(tmp, &statusRes)
-	if err != nil {
-		log.Printf("Unable to unmarshal status response: %v\n", err)
-		return err.Error()
+func (export *Export) RequestStatus() (string, error) {
+	return export.RequestStatusWithContext(context.Background())
+}
+
+func (export *Export) RequestStatusWithContext(ctx context.Context) (string, error) {
+	statusRes, err := export.RequestStatusDetailWithContext(ctx)
+	return statusRes.Status, err
+}
+
+func (export *Export) RequestStatusDetail() (ExportStatusResponse, error) {
+	return export.RequestStatusDetailWithContext(context.Background())
+}
 
+// RequestStatusDetailWithContext returns the full status payload, including failed and cancelled chunks and the
+// filters the export was requested with. Like RequestStatus it updates ExportStatus and AvailableChunks.
+func (export *Export) RequestStatusDetailWithContext(ctx context.Context) (ExportStatusResponse, error) {
+	statusRes, err := export.fetchStatus(ctx)
+	if err != nil {
+		return statusRes, err
 	}
 	export.ExportStatus = statusRes.Status
 	export.AvailableChunks = statusRes.ChunksAvailable
-	log.Printf("Export Status: %v\n", export.ExportStatus)
-	return export.ExportStatus
+	return statusRes, nil
+}
+
+func (export *Export) Cancel() (string, error) {
+	return export.CancelWithContext(context.Background())
+}
+
+// CancelWithContext asks Tenable.io to stop preparing the export and returns the resulting status. Chunks that are
+// already available can still be downloaded.
+func (export *Export) CancelWithContext(ctx context.Context) (status string, err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.Cancel",
+		export.ExportType+"/export/{uuid}/cancel", export.spanAttributes()...)
+	defer func() { endSpan(span, err) }()
+
+	resp, err := export.tioClient.PostWithContext(ctx, fmt.Sprintf("%v/export/%v/cancel", export.ExportType,
+		export.ExportUUID), nil)
+	if err != nil {
+		return "", err
+	}
+	var cancelRes struct {
+		Status string `json:"status"`
+	}
+	if err = decodeResponse(resp, &cancelRes); err != nil {
+		return "", err
+	}
+	export.tioClient.BaseClient.logger().Info("Cancelled export", "type", export.ExportType,
+		"export_uuid", export.ExportUUID, "status", cancelRes.Status)
+	export.ExportStatus = cancelRes.Status
+	return cancelRes.Status, nil
+}
+
+func (io *TenableIO) ListExports(exportType string) ([]ExportStatusResponse, error) {
+	return io.ListExportsWithContext(context.Background(), exportType)
+}
+
+// ListExportsWithContext lists the recent exports of the given type, e.g. ExportTypeVulns, with their statuses and
+// filters. Use ResumeExport with the UUID and type to download one of them.
+func (io *TenableIO) ListExportsWithContext(ctx context.Context, exportType string) (exports []ExportStatusResponse, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.ListExports", exportType+"/export/status",
+		Attribute{Key: AttrExportType, Value: exportType})
+	defer func() { endSpan(span, err) }()
+
+	resp, err := io.GetWithContext(ctx, fmt.Sprintf("%v/export/status", exportType), "")
+	if err != nil {
+		return nil, err
+	}
+	var listRes ExportListResponse
+	if err = decodeResponse(resp, &listRes); err != nil {
+		return nil, err
+	}
+	return listRes.Exports, nil
+}
+
+// fetchStatus requests the export status without updating the Export, so Run can apply it under its own lock.
+func (export *Export) fetchStatus(ctx context.Context) (statusRes ExportStatusResponse, err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.RequestStatus",
+		export.ExportType+"/export/{uuid}/status", export.spanAttributes()...)
+	defer func() { endSpan(span, err) }()
+
+	resp, err := export.tioClient.GetWithContext(ctx, fmt.Sprintf("%v/export/%v/status", export.ExportType, export.ExportUUID),
+		"")
+	if err != nil {
+		return statusRes, err
+	}
+	if err = decodeResponse(resp, &statusRes); err != nil {
+		return statusRes, err
+	}
+	export.tioClient.BaseClient.logger().Debug("Fetched export status", "export_uuid", export.ExportUUID,
+		"status", statusRes.Status)
+	return statusRes, nil
+}
+
+func (export *Export) DownloadChunk(ChunkID int) (AssetChunkDownloadResponse, error) {
+	return export.DownloadChunkWithContext(context.Background(), ChunkID)
+}
+
+func (export *Export) DownloadChunkWithContext(ctx context.Context, ChunkID int) (chunk AssetChunkDownloadResponse, err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.DownloadChunk",
+		export.ExportType+"/export/{uuid}/chunks/{id}",
+		append(export.spanAttributes(), Attribute{Key: AttrChunkID, Value: ChunkID})...)
+	defer func() { endSpan(span, err) }()
+
+	chunk = AssetChunkDownloadResponse{}
+	err = export.streamChunk(ctx, ChunkID, func(dec *json.Decoder) error {
+		var asset ExportedAsset
+		if err := dec.Decode(&asset); err != nil {
+			return err
+		}
+		chunk = append(chunk, asset)
+		return nil
+	})
+	return chunk, err
+}
+
+func (export *Export) StreamChunk(ChunkID int, fn func(ExportedAsset) error) error {
+	return export.StreamChunkWithContext(context.Background(), ChunkID, fn)
+}
+
+// StreamChunkWithContext downloads an asset export chunk and calls fn for each asset as it is decoded, so only one
+// asset is held in memory at a time. Iteration stops at the first error returned by fn, which is returned as is.
+func (export *Export) StreamChunkWithContext(ctx context.Context, ChunkID int, fn func(ExportedAsset) error) (err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.StreamChunk",
+		export.ExportType+"/export/{uuid}/chunks/{id}",
+		append(export.spanAttributes(), Attribute{Key: AttrChunkID, Value: ChunkID})...)
+	defer func() { endSpan(span, err) }()
+
+	return export.streamChunk(ctx, ChunkID, func(dec *json.Decoder) error {
+		var asset ExportedAsset
+		if err := dec.Decode(&asset); err != nil {
+			return err
+		}
+		if err := fn(asset); err != nil {
+			return callbackError{err}
+		}
+		return nil
+	})
 }
 
-func (export *Export) DownloadChunk(ChunkID int) AssetChunkDownloadResponse {
-	resp, err := export.tioClient.Get(fmt.Sprintf("%v/export/%v/chunks/%v", export.ExportType,
+// streamChunk requests a chunk and calls decode with the decoder positioned at each record.
+func (export *Export) streamChunk(ctx context.Context, ChunkID int, decode func(dec *json.Decoder) error) error {
+	resp, err := export.tioClient.GetWithContext(ctx, fmt.Sprintf("%v/export/%v/chunks/%v", export.ExportType,
 		export.ExportUUID, ChunkID), "")
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	var ChunkResponse = AssetChunkDownloadResponse{}
-	err = json.Unmarshal(tmp, &ChunkResponse)
 	if err != nil {
-		log.Printf("Unable to unmarshal asset chunk: %v\n", err)
+		return err
+	}
+	return streamResponse(resp, func(dec *json.Decoder) error {
+		return forEachElement(dec, decode)
+	})
+}
+
+func (export *Export) spanAttributes() []Attribute {
+	return []Attribute{
+		{Key: AttrExportType, Value: export.ExportType},
+		{Key: AttrExportUUID, Value: export.ExportUUID},
 	}
-	return ChunkResponse
 }
 
 type Export struct {
@@ -78,9 +226,28 @@ type Export struct {
 	ExportStatus    string
 	AvailableChunks []int
 	ProcessedChunks []int
-	tioClient       TenableIO
+	// Filters is the request body sent by RequestExport and RequestedAt the time it was sent.
+	Filters     json.RawMessage
+	RequestedAt time.Time
+	tioClient   TenableIO
 }
 
+// Export types accepted by TenableIO.NewExport.
+const (
+	ExportTypeAssets     = "assets"
+	ExportTypeVulns      = "vulns"
+	ExportTypeCompliance = "compliance"
+)
+
+// Export statuses reported by RequestStatus.
+const (
+	ExportStatusQueued     = "QUEUED"
+	ExportStatusProcessing = "PROCESSING"
+	ExportStatusFinished   = "FINISHED"
+	ExportStatusCancelled  = "CANCELLED"
+	ExportStatusError      = "ERROR"
+)
+
 func (io TenableIO) NewExport(exportType string) Export {
 	var ret = Export{}
 	ret.ExportType = exportType
@@ -88,33 +255,81 @@ func (io TenableIO) NewExport(exportType string) Export {
 	return ret
 }
 
+// NewExportService is NewExport behind the IOExportService interface, for code that creates exports through
+// TenableIOService and can be handed a FakeTenableIO.
+func (io TenableIO) NewExportService(exportType string) IOExportService {
+	export := io.NewExport(exportType)
+	return &export
+}
+
 type ExportRequestResponse struct {
 	ExportUUID string `json:"export_uuid"`
 }
 
+// ExportStatusResponse is the status of a single export. The status endpoint of one export and the export list
+// report different subsets of the fields; the ones not reported are left zero.
 type ExportStatusResponse struct {
-	Status          string `json:"status"`
-	ChunksAvailable []int  `json:"chunks_available"`
+	UUID                 string `json:"uuid,omitempty"`
+	Status               string `json:"status"`
+	ChunksAvailable      []int  `json:"chunks_available"`
+	ChunksFailed         []int  `json:"chunks_failed,omitempty"`
+	ChunksCancelled      []int  `json:"chunks_cancelled,omitempty"`
+	TotalChunks          int    `json:"total_chunks,omitempty"`
+	ChunksAvailableCount int    `json:"chunks_available_count,omitempty"`
+	EmptyChunksCount     int    `json:"empty_chunks_count,omitempty"`
+	FinishedChunks       int    `json:"finished_chunks,omitempty"`
+	NumAssetsPerChunk    int    `json:"num_assets_per_chunk,omitempty"`
+	// Filters holds the filters the export was requested with, in the form Tenable.io reports them.
+	Filters    json.RawMessage `json:"filters,omitempty"`
+	Created    UnixMillis      `json:"created,omitempty"`
+	Expiration UnixMillis      `json:"expiration,omitempty"`
+}
+
+type ExportListResponse struct {
+	Exports []ExportStatusResponse `json:"exports"`
 }
 
-type AssetChunkDownloadResponse []struct {
+// UnixMillis is a timestamp Tenable.io reports in milliseconds since the Unix epoch.
+type UnixMillis int64
+
+// Time converts the timestamp, returning the zero time for 0.
+func (m UnixMillis) Time() time.Time {
+	if m == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(m)*int64(time.Millisecond))
+}
+
+type AssetChunkDownloadResponse []ExportedAsset
+
+// ExportedAsset is a single asset from an asset export chunk. Fields Tenable.io adds later are ignored and null values
+// leave the zero value, so new or sparse records decode without errors.
+type ExportedAsset struct {
 	ID                        string    `json:"id,omitempty"`
 	HasAgent                  bool      `json:"has_agent,omitempty"`
 	HasPluginResults          bool      `json:"has_plugin_results,omitempty"`
 	CreatedAt                 time.Time `json:"created_at,omitempty"`
 	TerminatedAt              time.Time `json:"terminated_at,omitempty"`
-	TerminatedBy              time.Time `json:"terminated_by,omitempty"`
+	TerminatedBy              string    `json:"terminated_by,omitempty"`
 	UpdatedAt                 time.Time `json:"updated_at,omitempty"`
+	UpdatedBy                 string    `json:"updated_by,omitempty"`
 	DeletedAt                 time.Time `json:"deleted_at,omitempty"`
-	DeletedBy                 time.Time `json:"deleted_by,omitempty"`
+	DeletedBy                 string    `json:"deleted_by,omitempty"`
 	FirstSeen                 time.Time `json:"first_seen,omitempty"`
 	LastSeen                  time.Time `json:"last_seen,omitempty"`
 	FirstScanTime             time.Time `json:"first_scan_time,omitempty"`
 	LastScanTime              time.Time `json:"last_scan_time,omitempty"`
 	LastAuthenticatedScanDate time.Time `json:"last_authenticated_scan_date,omitempty"`
 	LastLicensedScanDate      time.Time `json:"last_licensed_scan_date,omitempty"`
+	LastScanID                string    `json:"last_scan_id,omitempty"`
+	LastScheduleID            string    `json:"last_schedule_id,omitempty"`
+	NetworkID                 string    `json:"network_id,omitempty"`
+	NetworkName               string    `json:"network_name,omitempty"`
 	AzureVMID                 string    `json:"azure_vm_id,omitempty"`
 	AzureResourceID           string    `json:"azure_resource_id,omitempty"`
+	GCPProjectID              string    `json:"gcp_project_id,omitempty"`
+	GCPZone                   string    `json:"gcp_zone,omitempty"`
+	GCPInstanceID             string    `json:"gcp_instance_id,omitempty"`
 	AwsEc2InstanceAmiID       string    `json:"aws_ec2_instance_ami_id,omitempty"`
 	AwsEc2InstanceID          string    `json:"aws_ec2_instance_id,omitempty"`
 	AgentUUID                 string    `json:"agent_uuid,omitempty"`
@@ -133,6 +348,8 @@ type AssetChunkDownloadResponse []struct {
 	McafeeEpoGUID             string    `json:"mcafee_epo_guid,omitempty"`
 	McafeeEpoAgentGUID        string    `json:"mcafee_epo_agent_guid,omitempty"`
 	ServicenowSysid           string    `json:"servicenow_sysid,omitempty"`
+	BigfixAssetID             string    `json:"bigfix_asset_id,omitempty"`
+	SerialNumber              string    `json:"serial_number,omitempty"`
 	AgentNames                []string  `json:"agent_names,omitempty"`
 	Ipv4s                     []string  `json:"ipv4s,omitempty"`
 	Ipv6s                     []string  `json:"ipv6s,omitempty"`
@@ -147,23 +364,76 @@ type AssetChunkDownloadResponse []struct {
 	QualysHostIds             []string  `json:"qualys_host_ids,omitempty"`
 	ManufacturerTpmIds        []string  `json:"manufacturer_tpm_ids,omitempty"`
 	SymantecEpHardwareKeys    []string  `json:"symantec_ep_hardware_keys,omitempty"`
-	Sources                   []struct {
-		Name      string    `json:"name,omitempty"`
-		FirstSeen time.Time `json:"first_seen,omitempty"`
-		LastSeen  time.Time `json:"last_seen,omitempty"`
-	} `json:"sources,omitempty"`
-	Tags              []string `json:"tags,omitempty"`
-	NetworkInterfaces []struct {
-		Name         string   `json:"name,omitempty"`
-		Virtual      bool     `json:"virtual,omitempty"`
-		Aliased      bool     `json:"aliased,omitempty"`
-		Fqdns        []string `json:"fqdns,omitempty"`
-		MacAddresses []string `json:"mac_addresses,omitempty"`
-		Ipv4S        []string `json:"ipv4s,omitempty"`
-		Ipv6S        []string `json:"ipv6s,omitempty"`
-	} `json:"network_interfaces"`
+	// InstalledSoftware lists the CPEs of the software found on the asset.
+	InstalledSoftware []string                `json:"installed_software,omitempty"`
+	OpenPorts         []AssetOpenPort         `json:"open_ports,omitempty"`
+	Sources           []AssetSource           `json:"sources,omitempty"`
+	Tags              []AssetTag              `json:"tags,omitempty"`
+	ResourceTags      []AssetResourceTag      `json:"resource_tags,omitempty"`
+	NetworkInterfaces []AssetNetworkInterface `json:"network_interfaces,omitempty"`
+	// ACRScore is the Asset Criticality Rating (1 to 10) and ACRDrivers the characteristics it was derived from.
+	ACRScore   float64          `json:"acr_score,omitempty"`
+	ACRDrivers []AssetACRDriver `json:"acr_drivers,omitempty"`
+	// ExposureScore is the Asset Exposure Score (0 to 1000), which combines the ACR with the asset's VPR scores.
+	ExposureScore           float64              `json:"exposure_score,omitempty"`
+	ExposureConfidenceValue float64              `json:"exposure_confidence_value,omitempty"`
+	ScanFrequency           []AssetScanFrequency `json:"scan_frequency,omitempty"`
+}
+
+type AssetOpenPort struct {
+	Port         int       `json:"port,omitempty"`
+	Protocol     string    `json:"protocol,omitempty"`
+	ServiceNames []string  `json:"service_names,omitempty"`
+	FirstSeen    time.Time `json:"first_seen,omitempty"`
+	LastSeen     time.Time `json:"last_seen,omitempty"`
 }
 
+type AssetSource struct {
+	Name      string    `json:"name,omitempty"`
+	FirstSeen time.Time `json:"first_seen,omitempty"`
+	LastSeen  time.Time `json:"last_seen,omitempty"`
+}
+
+// AssetTag is a Tenable.io tag applied to an asset, a value within a tag category.
+type AssetTag struct {
+	UUID    string    `json:"uuid,omitempty"`
+	Key     string    `json:"key,omitempty"`
+	Value   string    `json:"value,omitempty"`
+	AddedBy string    `json:"added_by,omitempty"`
+	AddedAt time.Time `json:"added_at,omitempty"`
+}
+
+// AssetResourceTag is a tag imported from a cloud provider, such as an AWS EC2 instance tag.
+type AssetResourceTag struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type AssetNetworkInterface struct {
+	Name         string   `json:"name,omitempty"`
+	Virtual      bool     `json:"virtual,omitempty"`
+	Aliased      bool     `json:"aliased,omitempty"`
+	Fqdns        []string `json:"fqdns,omitempty"`
+	MacAddresses []string `json:"mac_addresses,omitempty"`
+	Ipv4S        []string `json:"ipv4s,omitempty"`
+	Ipv6S        []string `json:"ipv6s,omitempty"`
+}
+
+type AssetACRDriver struct {
+	DriverName  string   `json:"driver_name,omitempty"`
+	DriverValue []string `json:"driver_value,omitempty"`
+}
+
+type AssetScanFrequency struct {
+	Interval  int  `json:"interval,omitempty"`
+	Frequency int  `json:"frequency,omitempty"`
+	Licensed  bool `json:"licensed,omitempty"`
+}
+
+// AssetRequestBody is the original asset export request. Its time filters are float32, which cannot hold current
+// Unix timestamps exactly, and its boolean filters cannot be set to false.
+//
+// Deprecated: use AssetExportRequest.
 type AssetRequestBody struct {
 	ChunkSize                 int      `json:"chunk_size"`
 	CreatedAt                 float32  `json:"filters.created_at,omitempty"`
@@ -179,9 +449,126 @@ type AssetRequestBody struct {
 }
 
 func (req AssetRequestBody) ToBytes() []byte {
-	ret, err := json.Marshal(req)
-	if err != nil {
-		log.Printf("Unable to marshal request body\n")
-	}
+	ret, _ := json.Marshal(req)
 	return ret
 }
+
+// Bounds Tenable.io enforces on the chunk_size of an asset export.
+const (
+	MinAssetChunkSize = 100
+	MaxAssetChunkSize = 10000
+)
+
+// AssetExportRequest builds the body of an asset export request. Zero times, nil booleans and empty strings are left
+// out of the request, so only the filters that are set apply. Use Bool to set a boolean filter:
+//
+//	req := go_tenable.AssetExportRequest{
+//		ChunkSize:    1000,
+//		UpdatedAt:    time.Now().AddDate(0, 0, -1),
+//		IsTerminated: go_tenable.Bool(false),
+//		Tags:         map[string][]string{"Location": {"Paris"}},
+//	}
+//	body, err := req.Build()
+type AssetExportRequest struct {
+	// ChunkSize is the number of assets in each chunk, between MinAssetChunkSize and MaxAssetChunkSize.
+	ChunkSize int
+
+	// The time filters return assets whose corresponding timestamp is after the given time.
+	CreatedAt                 time.Time
+	UpdatedAt                 time.Time
+	TerminatedAt              time.Time
+	DeletedAt                 time.Time
+	FirstScanTime             time.Time
+	LastAuthenticatedScanTime time.Time
+	LastAssessed              time.Time
+	// Since returns assets that were created, updated, terminated or deleted after the given time.
+	Since time.Time
+
+	IsTerminated     *bool
+	IsDeleted        *bool
+	IsLicensed       *bool
+	HasPluginResults *bool
+	ServiceNowSysID  *bool
+
+	Sources []string
+	// Tags maps a tag category to the values an asset must have, e.g. {"Location": {"Paris", "Lyon"}}.
+	Tags      map[string][]string
+	NetworkID string
+}
+
+// Bool returns a pointer to v, for the tri-state boolean filters of AssetExportRequest.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Validate checks the request against the limits Tenable.io enforces, so mistakes are reported before an export is
+// queued.
+func (req AssetExportRequest) Validate() error {
+	if req.ChunkSize < MinAssetChunkSize || req.ChunkSize > MaxAssetChunkSize {
+		return fmt.Errorf("invalid asset export request: chunk_size %v is not between %v and %v", req.ChunkSize,
+			MinAssetChunkSize, MaxAssetChunkSize)
+	}
+	for category, values := range req.Tags {
+		if category == "" || len(values) == 0 {
+			return fmt.Errorf("invalid asset export request: tag category %q needs a category name and values", category)
+		}
+	}
+	if req.NetworkID != "" && !uuidSegment.MatchString(req.NetworkID) {
+		return fmt.Errorf("invalid asset export request: network_id %q is not a UUID", req.NetworkID)
+	}
+	return nil
+}
+
+// Build validates the request and renders it as the JSON body the assets/export endpoint expects.
+func (req AssetExportRequest) Build() ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(req)
+}
+
+// MarshalJSON renders the request with times as Unix timestamps and one "tag.<category>" filter per tag category.
+// It does not validate the request; use Build for that.
+func (req AssetExportRequest) MarshalJSON() ([]byte, error) {
+	filters := make(map[string]interface{})
+	for key, t := range map[string]time.Time{
+		"created_at":                   req.CreatedAt,
+		"updated_at":                   req.UpdatedAt,
+		"terminated_at":                req.TerminatedAt,
+		"deleted_at":                   req.DeletedAt,
+		"first_scan_time":              req.FirstScanTime,
+		"last_authenticated_scan_time": req.LastAuthenticatedScanTime,
+		"last_assessed":                req.LastAssessed,
+		"since":                        req.Since,
+	} {
+		if !t.IsZero() {
+			filters[key] = t.Unix()
+		}
+	}
+	for key, b := range map[string]*bool{
+		"is_terminated":      req.IsTerminated,
+		"is_deleted":         req.IsDeleted,
+		"is_licensed":        req.IsLicensed,
+		"has_plugin_results": req.HasPluginResults,
+		"servicenow_sysid":   req.ServiceNowSysID,
+	} {
+		if b != nil {
+			filters[key] = *b
+		}
+	}
+	if len(req.Sources) > 0 {
+		filters["sources"] = req.Sources
+	}
+	for category, values := range req.Tags {
+		filters["tag."+category] = values
+	}
+	if req.NetworkID != "" {
+		filters["network_id"] = req.NetworkID
+	}
+
+	body := struct {
+		ChunkSize int                    `json:"chunk_size"`
+		Filters   map[string]interface{} `json:"filters,omitempty"`
+	}{req.ChunkSize, filters}
+	return json.Marshal(body)
+}
@@ -0,0 +1,90 @@
+package go_tenable
+
+import (
+	"context"
+)
+
+func (io *TenableIO) ListScans() (ScanListResponse, error) {
+	return io.ListScansWithContext(context.Background())
+}
+
+func (io *TenableIO) ListScansWithContext(ctx context.Context) (scanList ScanListResponse, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.ListScans", "scans")
+	defer func() { endSpan(span, err) }()
+
+	resp, err := io.GetWithContext(ctx, "scans", "")
+	if err != nil {
+		return scanList, err
+	}
+	err = decodeResponse(resp, &scanList)
+	return scanList, err
+}
+
+// Scans returns an iterator over the scans visible to the API keys. Tenable.io returns the whole list in a single
+// response, so the iterator makes one request.
+func (io *TenableIO) Scans(ctx context.Context) *ScanIterator {
+	return &ScanIterator{newIterator(ctx, 0,
+		func(ctx context.Context, _ int, _ int, _ interface{}) ([]interface{}, int, error) {
+			scanList, err := io.ListScansWithContext(ctx)
+			if err != nil {
+				return nil, 0, err
+			}
+			items := make([]interface{}, len(scanList.Scans))
+			for i, scan := range scanList.Scans {
+				items[i] = scan
+			}
+			return items, len(items), nil
+		})}
+}
+
+// ScanIterator streams scans one at a time. See Iterator for usage.
+type ScanIterator struct {
+	*Iterator
+}
+
+// Value returns the scan Next advanced to.
+func (it *ScanIterator) Value() Scan {
+	scan, _ := it.Iterator.Value().(Scan)
+	return scan
+}
+
+// ForEach calls fn for every remaining scan, stopping at the first error.
+func (it *ScanIterator) ForEach(fn func(Scan) error) error {
+	return it.forEach(func(v interface{}) error {
+		return fn(v.(Scan))
+	})
+}
+
+type ScanListResponse struct {
+	Folders []struct {
+		ID          int    `json:"id"`
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		DefaultTag  int    `json:"default_tag"`
+		Custom      int    `json:"custom"`
+		UnreadCount int    `json:"unread_count"`
+	} `json:"folders"`
+	Scans     []Scan `json:"scans"`
+	Timestamp int    `json:"timestamp"`
+}
+
+type Scan struct {
+	ID                   int    `json:"id"`
+	UUID                 string `json:"uuid"`
+	Name                 string `json:"name"`
+	Type                 string `json:"type"`
+	Owner                string `json:"owner"`
+	Enabled              bool   `json:"enabled"`
+	FolderID             int    `json:"folder_id"`
+	Read                 bool   `json:"read"`
+	Status               string `json:"status"`
+	Shared               bool   `json:"shared"`
+	UserPermissions      int    `json:"user_permissions"`
+	CreationDate         int    `json:"creation_date"`
+	LastModificationDate int    `json:"last_modification_date"`
+	Control              bool   `json:"control"`
+	Starttime            string `json:"starttime"`
+	Timezone             string `json:"timezone"`
+	Rrules               string `json:"rrules"`
+	ScheduleUUID         string `json:"schedule_uuid"`
+}
@@ -0,0 +1,252 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SyncStateStore remembers the watermark of the last successful sync for each key. LoadWatermark returns the zero
+// time when no sync has completed yet.
+type SyncStateStore interface {
+	LoadWatermark(key string) (time.Time, error)
+	SaveWatermark(key string, watermark time.Time) error
+}
+
+// SyncOptions configures TenableIO.SyncAssets and TenableIO.SyncVulns.
+type SyncOptions struct {
+	// Store holds the watermarks. It is required.
+	Store SyncStateStore
+	// Key is the name the watermark is saved under. Defaults to the export type, so give each job its own key when
+	// several jobs sync the same export type with different filters.
+	Key string
+	// AssetRequest and VulnRequest hold any filters of their own. The sync sets their Since filter; ChunkSize
+	// defaults to 1000 assets.
+	AssetRequest AssetExportRequest
+	VulnRequest  VulnExportRequest
+	// Run configures how the export is downloaded.
+	Run ExportRunOptions
+	// OnDeleted and OnTerminated receive the assets SyncAssets finds deleted or terminated after the watermark,
+	// instead of the callback. Either may be nil, in which case those assets are only counted. Like the callback they
+	// are not called concurrently.
+	OnDeleted    func(ExportedAsset) error
+	OnTerminated func(ExportedAsset) error
+}
+
+// SyncResult describes a completed sync.
+type SyncResult struct {
+	// Since is the watermark the sync started from, zero for a first, full sync. Watermark is the new watermark that
+	// was saved: the time Tenable.io created the export, by its own clock.
+	Since     time.Time
+	Watermark time.Time
+	// Records is the number of records passed to the callback.
+	Records int
+	// Deleted and Terminated count the assets deleted or terminated after Since, which SyncAssets hands to
+	// SyncOptions.OnDeleted and SyncOptions.OnTerminated instead of the callback.
+	Deleted    int
+	Terminated int
+}
+
+func (io *TenableIO) SyncAssets(opts SyncOptions, fn func(ExportedAsset) error) (SyncResult, error) {
+	return io.SyncAssetsWithContext(context.Background(), opts, fn)
+}
+
+// SyncAssetsWithContext exports the assets that changed since the last successful sync, or every asset on the first
+// run, and saves a new watermark once all of them have been delivered. fn is not called concurrently. If the sync
+// fails the watermark is left unchanged, so the next run covers the same period again.
+func (io *TenableIO) SyncAssetsWithContext(ctx context.Context, opts SyncOptions, fn func(ExportedAsset) error) (result SyncResult, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.SyncAssets", "assets/export",
+		Attribute{Key: AttrExportType, Value: ExportTypeAssets})
+	defer func() { endSpan(span, err) }()
+
+	key, since, err := opts.watermark(ExportTypeAssets)
+	if err != nil {
+		return result, err
+	}
+	req := opts.AssetRequest
+	if req.ChunkSize == 0 {
+		req.ChunkSize = 1000
+	}
+	req.Since = since
+	payload, err := req.Build()
+	if err != nil {
+		return result, err
+	}
+
+	result.Since = since
+	var mu sync.Mutex
+	err = io.sync(ctx, opts, key, ExportTypeAssets, payload, &result, func(record ExportRecord) error {
+		asset := record.Asset
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case !since.IsZero() && asset.DeletedAt.After(since):
+			result.Deleted++
+			if opts.OnDeleted != nil {
+				return opts.OnDeleted(*asset)
+			}
+			return nil
+		case !since.IsZero() && asset.TerminatedAt.After(since):
+			result.Terminated++
+			if opts.OnTerminated != nil {
+				return opts.OnTerminated(*asset)
+			}
+			return nil
+		}
+		result.Records++
+		return fn(*asset)
+	})
+	return result, err
+}
+
+func (io *TenableIO) SyncVulns(opts SyncOptions, fn func(VulnerabilityFinding) error) (SyncResult, error) {
+	return io.SyncVulnsWithContext(context.Background(), opts, fn)
+}
+
+// SyncVulnsWithContext exports the findings that changed state since the last successful sync, or every finding on
+// the first run, and saves a new watermark once all of them have been delivered. On incremental runs the state filter
+// defaults to OPEN, REOPENED and FIXED, so findings fixed since the last run are delivered too. fn is not called
+// concurrently. Tenable.io does not accept the since filter together with FirstFound, LastFound or LastFixed, so a
+// VulnRequest that sets them is rejected before the watermark is read.
+func (io *TenableIO) SyncVulnsWithContext(ctx context.Context, opts SyncOptions, fn func(VulnerabilityFinding) error) (result SyncResult, err error) {
+	ctx, span := io.BaseClient.startSpan(ctx, "TenableIO.SyncVulns", "vulns/export",
+		Attribute{Key: AttrExportType, Value: ExportTypeVulns})
+	defer func() { endSpan(span, err) }()
+
+	req := opts.VulnRequest
+	if !req.FirstFound.IsZero() || !req.LastFound.IsZero() || !req.LastFixed.IsZero() {
+		return result, fmt.Errorf("sync %v: VulnRequest cannot set FirstFound, LastFound or LastFixed", ExportTypeVulns)
+	}
+	key, since, err := opts.watermark(ExportTypeVulns)
+	if err != nil {
+		return result, err
+	}
+	req.Since = since
+	if !since.IsZero() && len(req.State) == 0 {
+		req.State = []string{"OPEN", "REOPENED", "FIXED"}
+	}
+	payload, err := req.Build()
+	if err != nil {
+		return result, err
+	}
+
+	result.Since = since
+	var mu sync.Mutex
+	err = io.sync(ctx, opts, key, ExportTypeVulns, payload, &result, func(record ExportRecord) error {
+		mu.Lock()
+		defer mu.Unlock()
+		result.Records++
+		return fn(*record.Vuln)
+	})
+	return result, err
+}
+
+// watermark returns the store key and the watermark the sync starts from.
+func (opts SyncOptions) watermark(exportType string) (string, time.Time, error) {
+	if opts.Store == nil {
+		return "", time.Time{}, fmt.Errorf("sync %v: SyncOptions.Store is required", exportType)
+	}
+	key := opts.Key
+	if key == "" {
+		key = exportType
+	}
+	since, err := opts.Store.LoadWatermark(key)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("loading sync watermark: %w", err)
+	}
+	return key, since, nil
+}
+
+// sync requests the export, runs it and saves the new watermark in the store and result. The watermark is the
+// creation time Tenable.io reports for the export, so a client clock running ahead of the server's cannot make the
+// next run skip changes; the local request time is only used if the status carries no creation time.
+func (io *TenableIO) sync(ctx context.Context, opts SyncOptions, key string, exportType string, payload []byte,
+	result *SyncResult, fn func(ExportRecord) error) error {
+	export := io.NewExport(exportType)
+	if _, err := export.RequestExportWithContext(ctx, payload); err != nil {
+		return err
+	}
+	status, err := export.RequestStatusDetailWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	watermark := export.RequestedAt.Round(0)
+	if status.Created != 0 {
+		watermark = status.Created.Time()
+	}
+	io.BaseClient.logger().Info("Started sync", "type", exportType, "key", key, "since", result.Since,
+		"export_uuid", export.ExportUUID)
+	if err := export.RunWithContext(ctx, opts.Run, fn); err != nil {
+		return err
+	}
+	if err := opts.Store.SaveWatermark(key, watermark); err != nil {
+		return fmt.Errorf("saving sync watermark: %w", err)
+	}
+	result.Watermark = watermark
+	return nil
+}
+
+// FileSyncStateStore keeps the watermarks of every key in a single JSON file. The file is replaced atomically.
+type FileSyncStateStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func NewFileSyncStateStore(path string) *FileSyncStateStore {
+	return &FileSyncStateStore{Path: path}
+}
+
+func (s *FileSyncStateStore) LoadWatermark(key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	watermarks, err := s.read()
+	return watermarks[key], err
+}
+
+func (s *FileSyncStateStore) SaveWatermark(key string, watermark time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	watermarks, err := s.read()
+	if err != nil {
+		return err
+	}
+	watermarks[key] = watermark
+	data, err := json.MarshalIndent(watermarks, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), ".watermarks-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+func (s *FileSyncStateStore) read() (map[string]time.Time, error) {
+	watermarks := make(map[string]time.Time)
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return watermarks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &watermarks); err != nil {
+		return nil, fmt.Errorf("unable to decode sync state %v: %w", s.Path, err)
+	}
+	return watermarks, nil
+}
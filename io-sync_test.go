@@ -0,0 +1,171 @@
+package go_tenable_test
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+// memoryStore is a SyncStateStore that counts how often it is read.
+type memoryStore struct {
+	mu         sync.Mutex
+	watermarks map[string]time.Time
+	loads      int
+}
+
+func (s *memoryStore) LoadWatermark(key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loads++
+	return s.watermarks[key], nil
+}
+
+func (s *memoryStore) SaveWatermark(key string, watermark time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watermarks[key] = watermark
+	return nil
+}
+
+func newSyncClient(t *testing.T, f tenabletest.Fixtures) (*tenabletest.IOServer, go_tenable.TenableIO) {
+	t.Helper()
+	srv := tenabletest.NewIOServer(f)
+	tio, err := go_tenable.NewTenableIO(f.IOAccessKey, f.IOSecretKey, srv.Options()...)
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	return srv, tio
+}
+
+var syncRun = go_tenable.ExportRunOptions{PollInterval: time.Millisecond}
+
+func TestSyncAssetsSavesTheExportCreationTime(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv, tio := newSyncClient(t, f)
+	defer srv.Close()
+	store := &memoryStore{watermarks: make(map[string]time.Time)}
+
+	result, err := tio.SyncAssets(go_tenable.SyncOptions{Store: store, Run: syncRun},
+		func(go_tenable.ExportedAsset) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Records != len(f.IOAssets) || !result.Since.IsZero() {
+		t.Errorf("got result %+v, want a full sync of %v assets", result, len(f.IOAssets))
+	}
+
+	exports, err := tio.ListExports(go_tenable.ExportTypeAssets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exports) != 1 {
+		t.Fatalf("got %v exports, want 1", len(exports))
+	}
+	created := exports[0].Created.Time()
+	if !result.Watermark.Equal(created) || !store.watermarks[go_tenable.ExportTypeAssets].Equal(created) {
+		t.Errorf("got watermark %v and saved %v, want the export creation time %v", result.Watermark,
+			store.watermarks[go_tenable.ExportTypeAssets], created)
+	}
+}
+
+func TestSyncAssetsKeepsTheWatermarkWhenRunFails(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv, tio := newSyncClient(t, f)
+	defer srv.Close()
+	since := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	store := &memoryStore{watermarks: map[string]time.Time{"nightly": since}}
+
+	stop := errors.New("stop")
+	_, err := tio.SyncAssets(go_tenable.SyncOptions{Store: store, Key: "nightly", Run: syncRun},
+		func(go_tenable.ExportedAsset) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Fatalf("got error %v, want the callback's error", err)
+	}
+	if got := store.watermarks["nightly"]; !got.Equal(since) {
+		t.Errorf("watermark moved from %v to %v after a failed sync", since, got)
+	}
+}
+
+func TestSyncAssetsHandsDeletedAndTerminatedAssetsToTheirCallbacks(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	since := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	f.IOAssets = f.IOAssets[:5]
+	f.IOAssets[1].DeletedAt = since.Add(time.Minute)
+	f.IOAssets[2].TerminatedAt = since.Add(time.Minute)
+	f.IOAssets[3].DeletedAt = since.Add(-time.Minute)
+	srv, tio := newSyncClient(t, f)
+	defer srv.Close()
+	store := &memoryStore{watermarks: map[string]time.Time{go_tenable.ExportTypeAssets: since}}
+
+	var updated, deleted, terminated []string
+	opts := go_tenable.SyncOptions{
+		Store: store,
+		Run:   syncRun,
+		OnDeleted: func(asset go_tenable.ExportedAsset) error {
+			deleted = append(deleted, asset.ID)
+			return nil
+		},
+		OnTerminated: func(asset go_tenable.ExportedAsset) error {
+			terminated = append(terminated, asset.ID)
+			return nil
+		},
+	}
+	result, err := tio.SyncAssets(opts, func(asset go_tenable.ExportedAsset) error {
+		updated = append(updated, asset.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(updated)
+
+	want := []string{f.IOAssets[0].ID, f.IOAssets[3].ID, f.IOAssets[4].ID}
+	if strings.Join(updated, ",") != strings.Join(want, ",") {
+		t.Errorf("callback got %v, want %v", updated, want)
+	}
+	if len(deleted) != 1 || deleted[0] != f.IOAssets[1].ID {
+		t.Errorf("OnDeleted got %v, want %v", deleted, f.IOAssets[1].ID)
+	}
+	if len(terminated) != 1 || terminated[0] != f.IOAssets[2].ID {
+		t.Errorf("OnTerminated got %v, want %v", terminated, f.IOAssets[2].ID)
+	}
+	if result.Records != 3 || result.Deleted != 1 || result.Terminated != 1 || !result.Since.Equal(since) {
+		t.Errorf("got result %+v", result)
+	}
+}
+
+func TestSyncVulnsRejectsFoundAndFixedFilters(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv, tio := newSyncClient(t, f)
+	defer srv.Close()
+	store := &memoryStore{watermarks: make(map[string]time.Time)}
+	day := time.Now().Add(-24 * time.Hour)
+
+	for name, req := range map[string]go_tenable.VulnExportRequest{
+		"FirstFound": {FirstFound: day},
+		"LastFound":  {LastFound: day},
+		"LastFixed":  {LastFixed: day},
+	} {
+		_, err := tio.SyncVulns(go_tenable.SyncOptions{Store: store, VulnRequest: req, Run: syncRun},
+			func(go_tenable.VulnerabilityFinding) error {
+				t.Errorf("%v: callback called", name)
+				return nil
+			})
+		if err == nil {
+			t.Errorf("%v: SyncVulns accepted the filter", name)
+		}
+	}
+	if store.loads != 0 {
+		t.Errorf("the watermark was read %v times", store.loads)
+	}
+	if exports, err := tio.ListExports(go_tenable.ExportTypeVulns); err != nil || len(exports) != 0 {
+		t.Errorf("ListExports returned %v, %v; want no exports", exports, err)
+	}
+}
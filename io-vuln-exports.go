@@ -0,0 +1,293 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func (export *Export) DownloadVulnChunk(ChunkID int) ([]VulnerabilityFinding, error) {
+	return export.DownloadVulnChunkWithContext(context.Background(), ChunkID)
+}
+
+// DownloadVulnChunkWithContext downloads a chunk of a vulnerability export created with NewExport("vulns").
+func (export *Export) DownloadVulnChunkWithContext(ctx context.Context, ChunkID int) (findings []VulnerabilityFinding, err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.DownloadVulnChunk",
+		export.ExportType+"/export/{uuid}/chunks/{id}",
+		append(export.spanAttributes(), Attribute{Key: AttrChunkID, Value: ChunkID})...)
+	defer func() { endSpan(span, err) }()
+
+	if err = export.requireType(ExportTypeVulns); err != nil {
+		return nil, err
+	}
+	findings = []VulnerabilityFinding{}
+	err = export.streamChunk(ctx, ChunkID, func(dec *json.Decoder) error {
+		var finding VulnerabilityFinding
+		if err := dec.Decode(&finding); err != nil {
+			return err
+		}
+		findings = append(findings, finding)
+		return nil
+	})
+	return findings, err
+}
+
+func (export *Export) StreamVulnChunk(ChunkID int, fn func(VulnerabilityFinding) error) error {
+	return export.StreamVulnChunkWithContext(context.Background(), ChunkID, fn)
+}
+
+// StreamVulnChunkWithContext downloads a vulnerability export chunk and calls fn for each finding as it is decoded.
+// Iteration stops at the first error returned by fn, which is returned as is.
+func (export *Export) StreamVulnChunkWithContext(ctx context.Context, ChunkID int, fn func(VulnerabilityFinding) error) (err error) {
+	ctx, span := export.tioClient.BaseClient.startSpan(ctx, "Export.StreamVulnChunk",
+		export.ExportType+"/export/{uuid}/chunks/{id}",
+		append(export.spanAttributes(), Attribute{Key: AttrChunkID, Value: ChunkID})...)
+	defer func() { endSpan(span, err) }()
+
+	if err = export.requireType(ExportTypeVulns); err != nil {
+		return err
+	}
+	return export.streamChunk(ctx, ChunkID, func(dec *json.Decoder) error {
+		var finding VulnerabilityFinding
+		if err := dec.Decode(&finding); err != nil {
+			return err
+		}
+		if err := fn(finding); err != nil {
+			return callbackError{err}
+		}
+		return nil
+	})
+}
+
+// requireType guards typed chunk downloads against decoding one export type's records as another's.
+func (export *Export) requireType(exportType string) error {
+	if export.ExportType != exportType {
+		return fmt.Errorf("export %v is a %q export; %q chunks cannot be decoded from it", export.ExportUUID,
+			export.ExportType, exportType)
+	}
+	return nil
+}
+
+// Bounds Tenable.io enforces on the num_assets of a vulnerability export.
+const (
+	MinVulnExportAssets = 50
+	MaxVulnExportAssets = 5000
+)
+
+// VulnExportRequest is the body of a vulnerability export request. Zero values are left out of the request, so only
+// the filters that are set apply.
+type VulnExportRequest struct {
+	// NumAssets is the number of assets whose findings are included in each chunk, between MinVulnExportAssets and
+	// MaxVulnExportAssets. Zero leaves it to Tenable.io, which uses 50.
+	NumAssets         int
+	IncludeUnlicensed bool
+
+	// Severity accepts "info", "low", "medium", "high" and "critical".
+	Severity []string
+	// State accepts "OPEN", "REOPENED" and "FIXED". Tenable.io returns only open and reopened findings by default.
+	State        []string
+	PluginFamily []string
+	PluginID     []int
+	// Since returns findings that changed state after the given time. It cannot be combined with FirstFound,
+	// LastFound or LastFixed.
+	Since      time.Time
+	FirstFound time.Time
+	LastFound  time.Time
+	LastFixed  time.Time
+	// Tags maps a tag category to the values an asset must have, e.g. {"Location": {"Paris", "Lyon"}}.
+	Tags      map[string][]string
+	NetworkID string
+	CIDRRange string
+}
+
+// Validate checks the request against the limits Tenable.io enforces, so mistakes are reported before an export is
+// queued.
+func (req VulnExportRequest) Validate() error {
+	if req.NumAssets != 0 && (req.NumAssets < MinVulnExportAssets || req.NumAssets > MaxVulnExportAssets) {
+		return fmt.Errorf("invalid vuln export request: num_assets %v is not between %v and %v", req.NumAssets,
+			MinVulnExportAssets, MaxVulnExportAssets)
+	}
+	if !req.Since.IsZero() && (!req.FirstFound.IsZero() || !req.LastFound.IsZero() || !req.LastFixed.IsZero()) {
+		return fmt.Errorf("invalid vuln export request: since cannot be combined with first_found, last_found or last_fixed")
+	}
+	for category, values := range req.Tags {
+		if category == "" || len(values) == 0 {
+			return fmt.Errorf("invalid vuln export request: tag category %q needs a category name and values", category)
+		}
+	}
+	if req.NetworkID != "" && !uuidSegment.MatchString(req.NetworkID) {
+		return fmt.Errorf("invalid vuln export request: network_id %q is not a UUID", req.NetworkID)
+	}
+	return nil
+}
+
+// Build validates the request and renders it as the JSON body the vulns/export endpoint expects.
+func (req VulnExportRequest) Build() ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(req)
+}
+
+// MarshalJSON renders the request in the shape the vulns/export endpoint expects, with times as Unix timestamps and
+// one "tag.<category>" filter per tag category. It does not validate the request; use Build for that.
+func (req VulnExportRequest) MarshalJSON() ([]byte, error) {
+	filters := make(map[string]interface{})
+	if len(req.Severity) > 0 {
+		filters["severity"] = req.Severity
+	}
+	if len(req.State) > 0 {
+		filters["state"] = req.State
+	}
+	if len(req.PluginFamily) > 0 {
+		filters["plugin_family"] = req.PluginFamily
+	}
+	if len(req.PluginID) > 0 {
+		filters["plugin_id"] = req.PluginID
+	}
+	for key, t := range map[string]time.Time{
+		"since":       req.Since,
+		"first_found": req.FirstFound,
+		"last_found":  req.LastFound,
+		"last_fixed":  req.LastFixed,
+	} {
+		if !t.IsZero() {
+			filters[key] = t.Unix()
+		}
+	}
+	for category, values := range req.Tags {
+		filters["tag."+category] = values
+	}
+	if req.NetworkID != "" {
+		filters["network_id"] = req.NetworkID
+	}
+	if req.CIDRRange != "" {
+		filters["cidr_range"] = req.CIDRRange
+	}
+
+	body := struct {
+		NumAssets         int                    `json:"num_assets,omitempty"`
+		IncludeUnlicensed bool                   `json:"include_unlicensed,omitempty"`
+		Filters           map[string]interface{} `json:"filters,omitempty"`
+	}{req.NumAssets, req.IncludeUnlicensed, filters}
+	return json.Marshal(body)
+}
+
+func (req VulnExportRequest) ToBytes() []byte {
+	ret, _ := json.Marshal(req)
+	return ret
+}
+
+// VulnerabilityFinding is a single finding from a vulnerability export chunk: a plugin result on one port of one
+// asset.
+type VulnerabilityFinding struct {
+	Asset                    VulnAsset  `json:"asset"`
+	Plugin                   VulnPlugin `json:"plugin"`
+	Port                     VulnPort   `json:"port"`
+	Scan                     VulnScan   `json:"scan"`
+	Output                   string     `json:"output"`
+	Severity                 string     `json:"severity"`
+	SeverityID               int        `json:"severity_id"`
+	SeverityDefaultID        int        `json:"severity_default_id"`
+	SeverityModificationType string     `json:"severity_modification_type"`
+	State                    string     `json:"state"`
+	FirstFound               time.Time  `json:"first_found"`
+	LastFound                time.Time  `json:"last_found"`
+	LastFixed                time.Time  `json:"last_fixed"`
+	Indexed                  time.Time  `json:"indexed"`
+}
+
+type VulnAsset struct {
+	UUID                       string    `json:"uuid"`
+	AgentUUID                  string    `json:"agent_uuid"`
+	BiosUUID                   string    `json:"bios_uuid"`
+	DeviceType                 string    `json:"device_type"`
+	FQDN                       string    `json:"fqdn"`
+	Hostname                   string    `json:"hostname"`
+	IPv4                       string    `json:"ipv4"`
+	IPv6                       string    `json:"ipv6"`
+	MacAddress                 string    `json:"mac_address"`
+	NetbiosName                string    `json:"netbios_name"`
+	NetbiosWorkgroup           string    `json:"netbios_workgroup"`
+	OperatingSystem            []string  `json:"operating_system"`
+	NetworkID                  string    `json:"network_id"`
+	Tracked                    bool      `json:"tracked"`
+	LastAuthenticatedResults   time.Time `json:"last_authenticated_results"`
+	LastUnauthenticatedResults time.Time `json:"last_unauthenticated_results"`
+}
+
+type VulnPlugin struct {
+	ID                   int        `json:"id"`
+	Name                 string     `json:"name"`
+	Family               string     `json:"family"`
+	FamilyID             int        `json:"family_id"`
+	Type                 string     `json:"type"`
+	Version              string     `json:"version"`
+	Description          string     `json:"description"`
+	Synopsis             string     `json:"synopsis"`
+	Solution             string     `json:"solution"`
+	RiskFactor           string     `json:"risk_factor"`
+	SeeAlso              []string   `json:"see_also"`
+	CPE                  []string   `json:"cpe"`
+	CVE                  []string   `json:"cve"`
+	BID                  []int      `json:"bid"`
+	XRefs                []VulnXRef `json:"xrefs"`
+	CVSSBaseScore        float64    `json:"cvss_base_score"`
+	CVSSTemporalScore    float64    `json:"cvss_temporal_score"`
+	CVSSVector           CVSSVector `json:"cvss_vector"`
+	CVSS3BaseScore       float64    `json:"cvss3_base_score"`
+	CVSS3TemporalScore   float64    `json:"cvss3_temporal_score"`
+	CVSS3Vector          CVSSVector `json:"cvss3_vector"`
+	VPR                  VPR        `json:"vpr"`
+	StigSeverity         string     `json:"stig_severity"`
+	ExploitAvailable     bool       `json:"exploit_available"`
+	ExploitabilityEase   string     `json:"exploitability_ease"`
+	ExploitedByMalware   bool       `json:"exploited_by_malware"`
+	ExploitedByNessus    bool       `json:"exploited_by_nessus"`
+	HasPatch             bool       `json:"has_patch"`
+	InTheNews            bool       `json:"in_the_news"`
+	UnsupportedByVendor  bool       `json:"unsupported_by_vendor"`
+	ChecksForMalware     bool       `json:"checks_for_malware"`
+	ChecksForDefaultAcct bool       `json:"checks_for_default_account"`
+	PublicationDate      time.Time  `json:"publication_date"`
+	ModificationDate     time.Time  `json:"modification_date"`
+	VulnPublicationDate  time.Time  `json:"vuln_publication_date"`
+	PatchPublicationDate time.Time  `json:"patch_publication_date"`
+}
+
+type VulnXRef struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// CVSSVector is a CVSS v2 or v3 vector broken into its metrics. Raw holds the vector string.
+type CVSSVector struct {
+	AccessComplexity      string `json:"access_complexity"`
+	AccessVector          string `json:"access_vector"`
+	Authentication        string `json:"authentication"`
+	ConfidentialityImpact string `json:"confidentiality_impact"`
+	IntegrityImpact       string `json:"integrity_impact"`
+	AvailabilityImpact    string `json:"availability_impact"`
+	Raw                   string `json:"raw"`
+}
+
+// VPR is the Vulnerability Priority Rating Tenable assigns to a plugin.
+type VPR struct {
+	Score   float64                `json:"score"`
+	Drivers map[string]interface{} `json:"drivers"`
+	Updated time.Time              `json:"updated"`
+}
+
+type VulnPort struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+	Service  string `json:"service"`
+}
+
+type VulnScan struct {
+	UUID         string    `json:"uuid"`
+	ScheduleUUID string    `json:"schedule_uuid"`
+	StartedAt    time.Time `json:"started_at"`
+	CompletedAt  time.Time `json:"completed_at"`
+}
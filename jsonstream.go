@@ -0,0 +1,157 @@
+package go_tenable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Streaming decoding
+//
+// json.Decoder.Decode buffers a whole value before unmarshaling it, so decoding a 100k asset export chunk in one
+// call holds the raw chunk and the decoded slice in memory together. The helpers below walk the body token by token
+// and decode the elements of large arrays one at a time instead.
+
+// streamResponse checks the response status and hands a decoder over the body to walk. The body is always closed.
+// Errors returned by walk are passed through, while malformed JSON is reported as an *APIError.
+func streamResponse(resp *http.Response, walk func(dec *json.Decoder) error) error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, err := readResponse(resp)
+		return err
+	}
+	defer drainAndClose(resp)
+
+	err := walk(json.NewDecoder(resp.Body))
+	var cbErr callbackError
+	if errors.As(err, &cbErr) {
+		return cbErr.err
+	}
+	if err != nil {
+		apiErr := newAPIError(resp, nil)
+		apiErr.Err = fmt.Errorf("unable to decode response: %w", err)
+		return apiErr
+	}
+	return nil
+}
+
+// scEnvelope holds the fields Tenable.sc wraps around every response.
+type scEnvelope struct {
+	Type      string
+	ErrorCode int
+	ErrorMsg  string
+	Warnings  []interface{}
+	Timestamp int
+}
+
+// streamSCResponse walks a Tenable.sc response with streamResponse, decoding the envelope fields into env and calling
+// walk with dec positioned at the value of "response", which walk must consume. A non-zero error_code is returned as
+// an *APIError, like decodeSCResponse does.
+func streamSCResponse(resp *http.Response, env *scEnvelope, walk func(dec *json.Decoder) error) error {
+	err := streamResponse(resp, func(dec *json.Decoder) error {
+		return forEachField(dec, func(key string, dec *json.Decoder) error {
+			switch key {
+			case "type":
+				return dec.Decode(&env.Type)
+			case "error_code":
+				return dec.Decode(&env.ErrorCode)
+			case "error_msg":
+				return dec.Decode(&env.ErrorMsg)
+			case "warnings":
+				return dec.Decode(&env.Warnings)
+			case "timestamp":
+				return dec.Decode(&env.Timestamp)
+			case "response":
+				return walk(dec)
+			default:
+				return skipValue(dec)
+			}
+		})
+	})
+	if err == nil && env.ErrorCode != 0 {
+		apiErr := newAPIError(resp, nil)
+		apiErr.ErrorCode = env.ErrorCode
+		apiErr.ErrorMsg = env.ErrorMsg
+		err = apiErr
+	}
+	return err
+}
+
+// callbackError marks an error returned by a caller's callback so streamResponse returns it unchanged.
+type callbackError struct {
+	err error
+}
+
+func (e callbackError) Error() string {
+	return e.err.Error()
+}
+
+// forEachElement reads a JSON array, calling fn with dec positioned at each element. fn must consume the element.
+// A null or string in place of the array is treated as empty, as in forEachField.
+func forEachElement(dec *json.Decoder, fn func(dec *json.Decoder) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if _, isString := tok.(string); tok == nil || isString {
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected JSON array, found %v", tok)
+	}
+	for dec.More() {
+		if err = fn(dec); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// forEachField reads a JSON object, calling fn with each key and dec positioned at its value. fn must consume the
+// value, with skipValue if it is not needed. A null or string in place of the object is treated as empty, since
+// Tenable.sc sends an empty string as the response of a failed request.
+func forEachField(dec *json.Decoder, fn func(key string, dec *json.Decoder) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if _, isString := tok.(string); tok == nil || isString {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected JSON object, found %v", tok)
+	}
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		if err = fn(key, dec); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// skipValue discards the next value without buffering it.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
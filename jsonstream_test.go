@@ -0,0 +1,60 @@
+package go_tenable_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thathaneydude/go-tenable"
+)
+
+func newSCListServer(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestSCListsStreamTheEnvelope(t *testing.T) {
+	srv := newSCListServer(`{"type": "regular", "response": [{"id": "1", "name": "Primary", "extra": {"a": [1, 2]}},
+		{"id": "2", "name": "DMZ"}], "error_code": 0, "error_msg": "", "warnings": [], "timestamp": 1600000000}`)
+	defer srv.Close()
+	sc, err := go_tenable.NewTenableSC("", go_tenable.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := sc.ListRepositories()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos.Repos) != 2 || repos.Repos[1].Name != "DMZ" || repos.Type != "regular" || repos.Timestamp != 1600000000 {
+		t.Errorf("got %+v", repos)
+	}
+}
+
+func TestSCListsReturnErrorEnvelopes(t *testing.T) {
+	// A failed request carries an empty string in place of the list, and error_code may follow it.
+	srv := newSCListServer(`{"type": "regular", "response": "", "error_code": 143,
+		"error_msg": "Repository fields are invalid", "warnings": [], "timestamp": 1600000000}`)
+	defer srv.Close()
+	sc, err := go_tenable.NewTenableSC("", go_tenable.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, list := range map[string]func() error{
+		"ListAssets":              func() error { _, err := sc.ListAssets(); return err },
+		"ListRepositories":        func() error { _, err := sc.ListRepositories(); return err },
+		"ListRiskAcceptanceRules": func() error { _, err := sc.ListRiskAcceptanceRules(); return err },
+		"ListRiskRecastRules":     func() error { _, err := sc.ListRiskRecastRules(); return err },
+	} {
+		var apiErr *go_tenable.APIError
+		if err := list(); !errors.As(err, &apiErr) || apiErr.ErrorCode != 143 ||
+			apiErr.ErrorMsg != "Repository fields are invalid" {
+			t.Errorf("%v: got error %v, want the error_code 143 envelope", name, err)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package go_tenable
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Logger receives the library's diagnostic output. Each call carries a message followed by alternating keys and
+// values, so *slog.Logger satisfies it directly and logrus, zap or zerolog can be wrapped in a few lines. Clients log
+// nothing unless a Logger is supplied with WithLogger.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// Level is the minimum severity written by a logger created with NewStdLogger.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// NopLogger discards everything. It is the default for every client.
+var NopLogger Logger = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// NewStdLogger adapts a standard library *log.Logger, writing entries at or above minLevel as
+// "LEVEL message key=value ...". A nil logger writes through the log package's default logger.
+func NewStdLogger(logger *log.Logger, minLevel Level) Logger {
+	return stdLogger{logger: logger, minLevel: minLevel}
+}
+
+type stdLogger struct {
+	logger   *log.Logger
+	minLevel Level
+}
+
+func (l stdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(LevelDebug, msg, keysAndValues)
+}
+
+func (l stdLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(LevelInfo, msg, keysAndValues)
+}
+
+func (l stdLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log(LevelWarn, msg, keysAndValues)
+}
+
+func (l stdLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(LevelError, msg, keysAndValues)
+}
+
+func (l stdLogger) log(level Level, msg string, keysAndValues []interface{}) {
+	if level < l.minLevel {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&b, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", keysAndValues[i])
+		}
+	}
+	if l.logger != nil {
+		l.logger.Print(b.String())
+	} else {
+		log.Print(b.String())
+	}
+}
+
+// WithLogger sends the client's diagnostic output to logger. Credentials are redacted before anything is logged.
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+func (bc baseClient) logger() Logger {
+	if bc.Logger == nil {
+		return NopLogger
+	}
+	return bc.Logger
+}
+
+// debugEnabled reports whether the client's logger may write debug entries, so that log fields which are expensive
+// to build, such as redacted request bodies, are only built when they can be written.
+func (bc baseClient) debugEnabled() bool {
+	switch l := bc.Logger.(type) {
+	case nil, nopLogger:
+		return false
+	case stdLogger:
+		return l.minLevel <= LevelDebug
+	}
+	return true
+}
+
+// Redaction
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are masked entirely whenever request headers are logged.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Apikey",
+	"X-Apikeys",
+	"X-Securitycenter",
+}
+
+// sensitiveFields are masked wherever they appear in a JSON body, compared case-insensitively.
+var sensitiveFields = []string{
+	"accesskey",
+	"access_key",
+	"apikey",
+	"api_key",
+	"authpassword",
+	"community",
+	"passphrase",
+	"password",
+	"privatekey",
+	"private_key",
+	"privpassword",
+	"secret",
+	"secretkey",
+	"secret_key",
+	"sessionid",
+	"token",
+}
+
+// redactHeaders returns a copy of header that is safe to log.
+func redactHeaders(header http.Header) map[string]string {
+	ret := make(map[string]string, len(header))
+	for key, values := range header {
+		if isSensitiveHeader(key) {
+			ret[key] = redacted
+		} else {
+			ret[key] = strings.Join(values, ", ")
+		}
+	}
+	return ret
+}
+
+func isSensitiveHeader(key string) bool {
+	canonical := http.CanonicalHeaderKey(key)
+	for _, h := range sensitiveHeaders {
+		if canonical == h {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody returns a loggable form of a JSON request or response body with sensitive fields masked. Bodies that
+// are not JSON cannot be inspected and are omitted entirely.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return fmt.Sprintf("[%d byte non-JSON body omitted]", len(body))
+	}
+	ret, err := json.Marshal(redactValue(decoded))
+	if err != nil {
+		return fmt.Sprintf("[%d byte body omitted]", len(body))
+	}
+	return string(ret)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if isSensitiveField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+	}
+	return value
+}
+
+func isSensitiveField(key string) bool {
+	lower := strings.ToLower(key)
+	for _, f := range sensitiveFields {
+		if lower == f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package go_tenable_test
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+// verbClient is the raw request API shared by TenableIO, TenableSC and Nessus.
+type verbClient interface {
+	Get(endpoint string, params string) (*http.Response, error)
+	Post(endpoint string, body []byte) (*http.Response, error)
+	Put(endpoint string, body []byte) (*http.Response, error)
+	Patch(endpoint string, body []byte) (*http.Response, error)
+	Delete(endpoint string, params string) (*http.Response, error)
+}
+
+const sensitiveBody = `{"name": "Linux", "credentials": {"password": "hunter2", "items": [{"token": "tok-8d1f"}],
+	"auth": {"secretKey": "sk-71c2"}}}`
+
+// sendEveryVerb sends one request with each HTTP verb and returns what the client logged for each of them.
+func sendEveryVerb(t *testing.T, client verbClient, buf *bytes.Buffer) map[string]string {
+	t.Helper()
+	logged := make(map[string]string)
+	for _, verb := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+		buf.Reset()
+		var resp *http.Response
+		var err error
+		switch verb {
+		case "GET":
+			resp, err = client.Get("scans", "")
+		case "POST":
+			resp, err = client.Post("scans", []byte(sensitiveBody))
+		case "PUT":
+			resp, err = client.Put("scans/1", []byte(sensitiveBody))
+		case "PATCH":
+			resp, err = client.Patch("scans/1", []byte(sensitiveBody))
+		case "DELETE":
+			resp, err = client.Delete("scans/1", "")
+		}
+		if err != nil {
+			t.Fatalf("%v: %v", verb, err)
+		}
+		resp.Body.Close()
+		logged[verb] = buf.String()
+	}
+	return logged
+}
+
+// checkRedacted fails the test if any log holds one of the secrets, or if a log lacks one of the masked entries.
+func checkRedacted(t *testing.T, logged map[string]string, secrets []string, masked []string) {
+	t.Helper()
+	for verb, entry := range logged {
+		if !strings.Contains(entry, "Sending request method="+verb) {
+			t.Errorf("%v: request was not logged: %q", verb, entry)
+			continue
+		}
+		for _, secret := range secrets {
+			if strings.Contains(entry, secret) {
+				t.Errorf("%v: log contains %q: %q", verb, secret, entry)
+			}
+		}
+		for _, m := range masked {
+			if !strings.Contains(entry, m) {
+				t.Errorf("%v: log lacks %q: %q", verb, m, entry)
+			}
+		}
+		if verb == "POST" || verb == "PUT" || verb == "PATCH" {
+			for _, field := range []string{`"password":"REDACTED"`, `"token":"REDACTED"`, `"secretKey":"REDACTED"`,
+				`"name":"Linux"`} {
+				if !strings.Contains(entry, field) {
+					t.Errorf("%v: log lacks body field %v: %q", verb, field, entry)
+				}
+			}
+		}
+	}
+}
+
+func newEchoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	}))
+}
+
+func TestLoggerRedactsAPIKeys(t *testing.T) {
+	srv := newEchoServer()
+	defer srv.Close()
+	var buf bytes.Buffer
+	opts := []go_tenable.Option{
+		go_tenable.WithBaseURL(srv.URL),
+		go_tenable.WithLogger(go_tenable.NewStdLogger(log.New(&buf, "", 0), go_tenable.LevelDebug)),
+	}
+	secrets := []string{"access-a1b2", "secret-c3d4", "hunter2", "tok-8d1f", "sk-71c2"}
+
+	tio, err := go_tenable.NewTenableIO("access-a1b2", "secret-c3d4", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRedacted(t, sendEveryVerb(t, tio, &buf), secrets, []string{"X-Apikeys:REDACTED"})
+
+	nessus, err := go_tenable.NewNessus("access-a1b2", "secret-c3d4", "", 0, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRedacted(t, sendEveryVerb(t, nessus, &buf), secrets, []string{"X-Apikeys:REDACTED"})
+
+	sc, err := go_tenable.NewTenableSCWithAPIKeys("", "access-a1b2", "secret-c3d4", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRedacted(t, sendEveryVerb(t, sc, &buf), secrets, []string{"X-Apikey:REDACTED"})
+}
+
+func TestLoggerRedactsSCSession(t *testing.T) {
+	srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+	defer srv.Close()
+	var buf bytes.Buffer
+	opts := append(srv.Options(),
+		go_tenable.WithLogger(go_tenable.NewStdLogger(log.New(&buf, "", 0), go_tenable.LevelDebug)))
+	sc, err := go_tenable.NewTenableSC("", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := sc.Login(tenabletest.SCUsername, tenabletest.SCPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"password":"REDACTED"`) {
+		t.Errorf("login log does not mask the password: %q", buf.String())
+	}
+
+	// The fake server answers the unknown endpoints with errors, which the raw request methods return as responses.
+	secrets := []string{"TNS_SESSIONID", fmt.Sprintf("X-Securitycenter:%v", token.Response.Token), "hunter2",
+		"tok-8d1f", "sk-71c2"}
+	checkRedacted(t, sendEveryVerb(t, sc, &buf), secrets, []string{"X-Securitycenter:REDACTED", "Cookie:REDACTED"})
+}
@@ -0,0 +1,108 @@
+package go_tenable
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Product identifies which Tenable product a client talks to.
+type Product string
+
+const (
+	ProductIO     Product = "io"
+	ProductSC     Product = "sc"
+	ProductNessus Product = "nessus"
+)
+
+// RequestMetrics describes a single HTTP attempt. Retries are reported as separate attempts with increasing Attempt
+// numbers, so counting attempts above 1 gives the retry rate.
+type RequestMetrics struct {
+	Product Product
+	Method  string
+	// Endpoint is the endpoint template with identifiers replaced, e.g. "assets/export/{uuid}/chunks/{id}", so it
+	// can be used as a low cardinality label.
+	Endpoint string
+	Attempt  int
+	// StatusCode is 0 when no response was received, in which case Err says why.
+	StatusCode int
+	Err        error
+	// Duration runs from sending the request until the response body is closed, so it includes reading the body.
+	Duration time.Duration
+	// ResponseSize is the number of response body bytes read by the client.
+	ResponseSize int64
+}
+
+// MetricsRecorder receives a RequestMetrics for every HTTP attempt a client makes. Implementations are called from
+// the goroutine that made the request and must be safe for concurrent use. Adapting it to Prometheus or OpenMetrics
+// takes a counter and a couple of histograms:
+//
+//	func (m promMetrics) ObserveRequest(r go_tenable.RequestMetrics) {
+//		labels := prometheus.Labels{"product": string(r.Product), "method": r.Method, "endpoint": r.Endpoint,
+//			"status": strconv.Itoa(r.StatusCode)}
+//		m.requests.With(labels).Inc()
+//		m.duration.With(labels).Observe(r.Duration.Seconds())
+//		m.size.With(labels).Observe(float64(r.ResponseSize))
+//	}
+type MetricsRecorder interface {
+	ObserveRequest(RequestMetrics)
+}
+
+// MetricsFunc adapts a function to the MetricsRecorder interface.
+type MetricsFunc func(RequestMetrics)
+
+func (f MetricsFunc) ObserveRequest(m RequestMetrics) {
+	f(m)
+}
+
+// WithMetrics reports every HTTP attempt made by the client to recorder.
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(o *clientOptions) {
+		o.metrics = recorder
+	}
+}
+
+var (
+	uuidSegment    = regexp.MustCompile(`^[0-9A-Fa-f]{8}-?[0-9A-Fa-f]{4}-?[0-9A-Fa-f]{4}-?[0-9A-Fa-f]{4}-?[0-9A-Fa-f]{12}$`)
+	numericSegment = regexp.MustCompile(`^-?[0-9]+$`)
+)
+
+// endpointTemplate replaces identifiers in an endpoint with placeholders, e.g. "asset/12" becomes "asset/{id}".
+func endpointTemplate(endpoint string) string {
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	for i, segment := range segments {
+		switch {
+		case uuidSegment.MatchString(segment):
+			segments[i] = "{uuid}"
+		case numericSegment.MatchString(segment):
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// meteredBody counts the bytes read from a response body and reports the attempt once the body is closed.
+type meteredBody struct {
+	io.ReadCloser
+	metrics  RequestMetrics
+	start    time.Time
+	recorder MetricsRecorder
+	once     sync.Once
+}
+
+func (b *meteredBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.metrics.ResponseSize += int64(n)
+	return n, err
+}
+
+func (b *meteredBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.metrics.Duration = time.Since(b.start)
+		b.recorder.ObserveRequest(b.metrics)
+	})
+	return err
+}
@@ -1,39 +1,40 @@
 package go_tenable
 
 import (
-	"encoding/json"
-	"io/ioutil"
-	"log"
+	"context"
 )
 
 // Public Functions
-func (n *Nessus) GetStatus() StatusResponse {
-	var statusResponse StatusResponse
-	resp, err := n.Get("server/status", "")
+func (n *Nessus) GetStatus() (StatusResponse, error) {
+	return n.GetStatusWithContext(context.Background())
+}
+
+func (n *Nessus) GetStatusWithContext(ctx context.Context) (statusResponse StatusResponse, err error) {
+	ctx, span := n.BaseClient.startSpan(ctx, "Nessus.GetStatus", "server/status")
+	defer func() { endSpan(span, err) }()
+
+	resp, err := n.GetWithContext(ctx, "server/status", "")
 	if err != nil {
-		log.Printf("Unable to fetch Nessus Scanner status: %v\n", err)
-	}
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	unmarshalError := json.Unmarshal(tmp, &statusResponse)
-	if unmarshalError != nil {
-		log.Printf("Unable to unmarshal status response: %v\n", unmarshalError)
+		return statusResponse, err
 	}
-	return statusResponse
+	err = decodeResponse(resp, &statusResponse)
+	return statusResponse, err
 }
 
-func (n *Nessus) GetProperties() PropertiesResponse {
-	var propertiesResponse PropertiesResponse
+func (n *Nessus) GetProperties() (PropertiesResponse, error) {
+	return n.GetPropertiesWithContext(context.Background())
+}
+
+func (n *Nessus) GetPropertiesWithContext(ctx context.Context) (propertiesResponse PropertiesResponse, err error) {
+	ctx, span := n.BaseClient.startSpan(ctx, "Nessus.GetProperties", "server/properties")
+	defer func() { endSpan(span, err) }()
 
-	resp, err := n.Get("server/properties", "")
+	resp, err := n.GetWithContext(ctx, "server/properties", "")
 	if err != nil {
-		log.Printf("Unable to fetch Nessus Scanner Properties: %v\n", err)
-	}
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	unmarshalError := json.Unmarshal(tmp, &propertiesResponse)
-	if unmarshalError != nil {
-		log.Printf("Unable to unmarshal properties response: %v\n", unmarshalError)
+		return propertiesResponse, err
 	}
-	return propertiesResponse
+	err = decodeResponse(resp, &propertiesResponse)
+	return propertiesResponse, err
 }
 
 // Nessus Structs
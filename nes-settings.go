@@ -1,29 +1,29 @@
 package go_tenable
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
-	"log"
 )
 
-func (n *Nessus) GetHealthStats(count int) ScannerSettingsResponse {
-	if count == 0 {
-		log.Printf("Zero is an invalid number of records to fetch. Setting to 1")
-	}
+func (n *Nessus) GetHealthStats(count int) (ScannerSettingsResponse, error) {
+	return n.GetHealthStatsWithContext(context.Background(), count)
+}
 
-	resp, err := n.Get("settings/health/stats", fmt.Sprintf("count=%v", count))
-	if err != nil {
-		log.Printf("Unable to fetch health stats for %v: %v", n.Address, err)
+func (n *Nessus) GetHealthStatsWithContext(ctx context.Context, count int) (healthResponse ScannerSettingsResponse, err error) {
+	ctx, span := n.BaseClient.startSpan(ctx, "Nessus.GetHealthStats", "settings/health/stats")
+	defer func() { endSpan(span, err) }()
 
+	if count == 0 {
+		n.BaseClient.logger().Warn("Zero is an invalid number of health records to fetch. Setting to 1")
+		count = 1
 	}
-	var healthResponse ScannerSettingsResponse
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	err = json.Unmarshal(tmp, &healthResponse)
+
+	resp, err := n.GetWithContext(ctx, "settings/health/stats", fmt.Sprintf("count=%v", count))
 	if err != nil {
-		log.Printf("Unable to unmarshal Health Seetings Response: %v", err)
+		return healthResponse, err
 	}
-	return healthResponse
+	err = decodeResponse(resp, &healthResponse)
+	return healthResponse, err
 }
 
 type ScannerSettingsResponse struct {
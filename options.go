@@ -0,0 +1,167 @@
+package go_tenable
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultUserAgent = "GoTenable"
+
+// Option configures a client created with NewTenableIO, NewTenableSC or NewNessus.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	baseURL      string
+	httpClient   *http.Client
+	roundTripper http.RoundTripper
+	timeout      time.Duration
+	userAgent    string
+	proxy        *url.URL
+	retryPolicy  *RetryPolicy
+	logger       Logger
+
+	sessionRenewal bool
+	onReauth       func(ReauthEvent)
+
+	rateLimit        *RateLimit
+	familyRateLimits map[EndpointFamily]RateLimit
+	onRateLimitWait  func(RateLimitWait)
+
+	metrics        MetricsRecorder
+	tracerProvider TracerProvider
+
+	scannerID int
+}
+
+// WithBaseURL overrides the URL every endpoint is resolved against, such as a regional or FedRAMP Tenable.io host or
+// a Tenable.sc console served behind a path prefix (e.g. "https://sc.example.com/tenable/rest").
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sends requests through the provided client. The client is copied, so options such as WithTimeout
+// and WithRoundTripper never modify the caller's value.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithRoundTripper sends requests through the provided transport, e.g. one instrumented for tracing or metrics.
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.roundTripper = rt
+	}
+}
+
+// WithTimeout bounds every request, including reading the response body.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgent replaces the default "GoTenable" User-Agent header.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithProxy routes requests through the given HTTP proxy. It requires the client's transport to be an
+// *http.Transport, which is the case unless WithRoundTripper or WithHTTPClient supply something else.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(o *clientOptions) {
+		o.proxy = proxyURL
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy for the client.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = &policy
+	}
+}
+
+func buildOptions(opts []Option) clientOptions {
+	o := clientOptions{userAgent: defaultUserAgent}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// resolveBaseURL validates the configured base URL, falling back to defaultURL, and strips any trailing slash so
+// endpoints can be appended with a single separator.
+func (o clientOptions) resolveBaseURL(defaultURL string) (string, error) {
+	baseURL := o.baseURL
+	if baseURL == "" {
+		baseURL = defaultURL
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q: an http or https scheme and a host are required", baseURL)
+	}
+	return strings.TrimRight(baseURL, "/"), nil
+}
+
+func newBaseClient(o clientOptions, product Product) (*baseClient, error) {
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		clientCopy := *o.httpClient
+		httpClient = &clientCopy
+	}
+	if o.roundTripper != nil {
+		httpClient.Transport = o.roundTripper
+	}
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+	if o.proxy != nil {
+		var transport *http.Transport
+		switch rt := httpClient.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = rt.Clone()
+		default:
+			return nil, errors.New("WithProxy requires the client transport to be an *http.Transport")
+		}
+		transport.Proxy = http.ProxyURL(o.proxy)
+		httpClient.Transport = transport
+	}
+
+	headers := &http.Header{}
+	headers.Set("Content-Type", "application/json")
+	headers.Set("User-Agent", o.userAgent)
+
+	b := &baseClient{
+		HttpClient:  httpClient,
+		Headers:     headers,
+		RetryPolicy: DefaultRetryPolicy,
+		Logger:      o.logger,
+		Metrics:     o.metrics,
+		product:     product,
+		headerMu:    &sync.RWMutex{},
+		limiter:     newRateLimiter(o),
+	}
+	if o.retryPolicy != nil {
+		b.RetryPolicy = *o.retryPolicy
+	}
+	if o.tracerProvider != nil {
+		b.tracer = o.tracerProvider.Tracer(tracerName)
+	}
+	return b, nil
+}
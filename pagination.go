@@ -0,0 +1,86 @@
+package go_tenable
+
+import (
+	"context"
+)
+
+// pageFetcher retrieves up to limit items starting at offset. last is the final item of the previous page (nil for
+// the first page) for endpoints that paginate with a cursor rather than an offset. total is the number of items the
+// endpoint reports in the full result set, or -1 when it does not say.
+type pageFetcher func(ctx context.Context, offset int, limit int, last interface{}) (items []interface{}, total int, err error)
+
+// Iterator streams a paginated result set one item at a time, holding at most one page in memory. Iterate with
+//
+//	for it.Next() {
+//		item := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Endpoint specific iterators such as AgentIterator embed Iterator and return typed values.
+type Iterator struct {
+	ctx     context.Context
+	fetch   pageFetcher
+	limit   int
+	offset  int
+	page    []interface{}
+	current interface{}
+	done    bool
+	err     error
+}
+
+func newIterator(ctx context.Context, limit int, fetch pageFetcher) *Iterator {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &Iterator{ctx: ctx, fetch: fetch, limit: limit}
+}
+
+// Next advances to the next item, fetching another page when the current one is exhausted. It returns false once
+// the result set is exhausted, the context is done or a request fails.
+func (it *Iterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		items, total, err := it.fetch(it.ctx, it.offset, it.limit, it.current)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.offset += len(items)
+		if len(items) == 0 || len(items) < it.limit || (total >= 0 && it.offset >= total) {
+			it.done = true
+		}
+		it.page = items
+	}
+	it.current = it.page[0]
+	it.page[0] = nil
+	it.page = it.page[1:]
+	return true
+}
+
+// Value returns the item Next advanced to.
+func (it *Iterator) Value() interface{} {
+	return it.current
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// forEach calls fn for every remaining item, stopping at the first error returned by fn or by a page request.
+func (it *Iterator) forEach(fn func(interface{}) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,187 @@
+package go_tenable
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token bucket: requests are allowed at Rate per second on average, with bursts of up to Burst
+// requests after a quiet period. A Burst below 1 is treated as 1, and a Rate of 0 disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// EndpointFamily groups endpoints that Tenable.io rate limits together.
+type EndpointFamily string
+
+const (
+	// FamilyExports covers the asset, vulnerability and compliance export endpoints, including chunk downloads.
+	FamilyExports EndpointFamily = "exports"
+	// FamilyAgents covers agent and agent group management below scanners/{id}.
+	FamilyAgents EndpointFamily = "agents"
+	// FamilyAuditLog covers audit-log/v1/events.
+	FamilyAuditLog EndpointFamily = "audit-log"
+)
+
+// RateLimitWait describes a request that was held back by a client-side rate limit. Family is empty when the wait
+// was imposed by the client-wide limit set with WithRateLimit.
+type RateLimitWait struct {
+	Family   EndpointFamily
+	Method   string
+	Endpoint string
+	Wait     time.Duration
+}
+
+// WithRateLimit limits every request sent by the client, including retries. The limit is shared by all copies of the
+// client, so goroutines using the same TenableIO value cooperate rather than each being throttled by Tenable.
+func WithRateLimit(limit RateLimit) Option {
+	return func(o *clientOptions) {
+		o.rateLimit = &limit
+	}
+}
+
+// WithEndpointRateLimit limits the requests to one endpoint family. It applies in addition to any client-wide limit
+// set with WithRateLimit.
+func WithEndpointRateLimit(family EndpointFamily, limit RateLimit) Option {
+	return func(o *clientOptions) {
+		if o.familyRateLimits == nil {
+			o.familyRateLimits = make(map[EndpointFamily]RateLimit)
+		}
+		o.familyRateLimits[family] = limit
+	}
+}
+
+// WithRateLimitObserver calls fn whenever a request has to wait for a rate limit, before the wait begins. Use it to
+// record how much time is spent throttled. fn may be called from several goroutines at once.
+func WithRateLimitObserver(fn func(RateLimitWait)) Option {
+	return func(o *clientOptions) {
+		o.onRateLimitWait = fn
+	}
+}
+
+// rateLimiter holds the buckets configured for a client. It is shared by pointer between copies of the client.
+type rateLimiter struct {
+	client   *tokenBucket
+	families map[EndpointFamily]*tokenBucket
+	observer func(RateLimitWait)
+}
+
+func newRateLimiter(o clientOptions) *rateLimiter {
+	if o.rateLimit == nil && len(o.familyRateLimits) == 0 {
+		return nil
+	}
+	l := &rateLimiter{
+		families: make(map[EndpointFamily]*tokenBucket, len(o.familyRateLimits)),
+		observer: o.onRateLimitWait,
+	}
+	if o.rateLimit != nil {
+		l.client = newTokenBucket(*o.rateLimit)
+	}
+	for family, limit := range o.familyRateLimits {
+		l.families[family] = newTokenBucket(limit)
+	}
+	return l
+}
+
+// wait blocks until both the client-wide bucket and the bucket for the request's endpoint family allow the request,
+// or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, logger Logger, method string, fullURL string) error {
+	if l == nil {
+		return nil
+	}
+	endpoint := fullURL
+	if parsed, err := url.Parse(fullURL); err == nil {
+		endpoint = parsed.Path
+	}
+
+	if err := l.take(ctx, logger, l.client, RateLimitWait{Method: method, Endpoint: endpoint}); err != nil {
+		return err
+	}
+	family := endpointFamily(endpoint)
+	if family == "" {
+		return nil
+	}
+	return l.take(ctx, logger, l.families[family], RateLimitWait{Family: family, Method: method, Endpoint: endpoint})
+}
+
+func (l *rateLimiter) take(ctx context.Context, logger Logger, bucket *tokenBucket, event RateLimitWait) error {
+	if bucket == nil {
+		return nil
+	}
+	event.Wait = bucket.reserve(time.Now())
+	if event.Wait <= 0 {
+		return nil
+	}
+	logger.Debug("Waiting for rate limit", "family", string(event.Family), "method", event.Method,
+		"endpoint", event.Endpoint, "wait", event.Wait)
+	if l.observer != nil {
+		l.observer(event)
+	}
+	if err := sleepContext(ctx, event.Wait); err != nil {
+		bucket.cancel()
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+	return nil
+}
+
+// endpointFamily classifies a request path, e.g. "/assets/export/{uuid}/chunks/1" is FamilyExports.
+func endpointFamily(path string) EndpointFamily {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "export" || segment == "exports":
+			return FamilyExports
+		case segment == "audit-log":
+			return FamilyAuditLog
+		case segment == "scanners" && i+2 < len(segments) && strings.HasPrefix(segments[i+2], "agent"):
+			return FamilyAgents
+		}
+	}
+	return ""
+}
+
+// tokenBucket hands out reservations rather than blocking, so the lock is never held while a caller sleeps. Tokens
+// may go negative; the deficit is how far in the future the latest reservation falls.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := math.Max(float64(limit.Burst), 1)
+	return &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate <= 0 {
+		return 0
+	}
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token whose reservation was abandoned.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,132 @@
+package go_tenable
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	bucket := newTokenBucket(RateLimit{Rate: 2, Burst: 3})
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if wait := bucket.reserve(start); wait != 0 {
+			t.Fatalf("reservation %v of the burst waits %v", i+1, wait)
+		}
+	}
+	// Each reservation past the burst waits for its share of the deficit at 2 tokens per second.
+	for i, want := range []time.Duration{500 * time.Millisecond, time.Second, 1500 * time.Millisecond} {
+		if wait := bucket.reserve(start); wait != want {
+			t.Errorf("reservation %v past the burst waits %v, want %v", i+1, wait, want)
+		}
+	}
+
+	// A returned token shortens the next wait, and time passing refills the bucket up to the burst only.
+	bucket.cancel()
+	if wait := bucket.reserve(start); wait != 1500*time.Millisecond {
+		t.Errorf("reservation after a cancel waits %v, want 1.5s", wait)
+	}
+	later := start.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		if wait := bucket.reserve(later); wait != 0 {
+			t.Fatalf("reservation %v after a quiet hour waits %v", i+1, wait)
+		}
+	}
+	if wait := bucket.reserve(later); wait != 500*time.Millisecond {
+		t.Errorf("reservation past the refilled burst waits %v, want 500ms", wait)
+	}
+
+	unlimited := newTokenBucket(RateLimit{})
+	for i := 0; i < 10; i++ {
+		if wait := unlimited.reserve(start); wait != 0 {
+			t.Fatalf("a zero rate waits %v", wait)
+		}
+	}
+}
+
+func TestEndpointFamily(t *testing.T) {
+	for path, want := range map[string]EndpointFamily{
+		"/assets/export":                    FamilyExports,
+		"/vulns/export/abc/status":          FamilyExports,
+		"/compliance/export/abc/chunks/1":   FamilyExports,
+		"/assets/export/abc/chunks/2":       FamilyExports,
+		"/vulns/export/status":              FamilyExports,
+		"/exports/compliance":               FamilyExports,
+		"/scanners/1/agents":                FamilyAgents,
+		"/scanners/1/agents/7":              FamilyAgents,
+		"/scanners/1/agent-groups/3/agents": FamilyAgents,
+		"/scanners/1/agents/_bulk/unlink":   FamilyAgents,
+		"/audit-log/v1/events":              FamilyAuditLog,
+		"/scanners/1":                       "",
+		"/scanners":                         "",
+		"/scans":                            "",
+		"/rest/asset":                       "",
+	} {
+		if got := endpointFamily(path); got != want {
+			t.Errorf("endpointFamily(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRateLimitObserverReceivesTheWait(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	var events []RateLimitWait
+	observer := func(event RateLimitWait) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+		// Cancelling ends the wait at once, so the test does not sleep through it.
+		cancel()
+	}
+	tio, err := NewTenableIO("access", "secret", WithBaseURL(srv.URL), WithRateLimitObserver(observer),
+		WithEndpointRateLimit(FamilyAuditLog, RateLimit{Rate: 0.5, Burst: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := tio.GetWithContext(ctx, "scans", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	resp, err := tio.GetWithContext(ctx, "audit-log/v1/events", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(events) != 0 {
+		t.Fatalf("observer called for requests within the limits: %+v", events)
+	}
+
+	_, err = tio.GetWithContext(ctx, "audit-log/v1/events", "")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want the cancellation during the wait", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 1 {
+		t.Fatalf("got events %+v, want 1", events)
+	}
+	event := events[0]
+	if event.Family != FamilyAuditLog || event.Method != "GET" || event.Endpoint != "/audit-log/v1/events" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if event.Wait <= time.Second || event.Wait > 2*time.Second {
+		t.Errorf("observer got wait %v, want just under 2s", event.Wait)
+	}
+}
@@ -0,0 +1,155 @@
+package go_tenable
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests are retried when Tenable rate limits them (429), when the product is temporarily
+// unavailable (5xx) or when the connection fails. A Retry-After header on the response always takes precedence over
+// the computed backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values below 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the base delay used for the exponential backoff between attempts.
+	MinBackoff time.Duration
+	// MaxBackoff caps both the computed backoff and any Retry-After value sent by the server.
+	MaxBackoff time.Duration
+	// RetryStatuses lists the HTTP statuses that are considered transient. Defaults to 429, 500, 502, 503 and 504.
+	RetryStatuses []int
+	// RetryNonIdempotent allows POST and PATCH requests to be retried after a 5xx or a connection error, where the
+	// request may already have been processed. They are always retried after a 429 since it was rejected outright.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is applied to every client created by this package.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// NoRetryPolicy sends each request exactly once.
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+var defaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func (p RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		// A request missing from a replayed cassette fails the same way on every attempt
+		if errors.Is(err, ErrInteractionNotFound) {
+			return false
+		}
+		return p.RetryNonIdempotent || isIdempotent(ctx, method)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	statuses := p.RetryStatuses
+	if statuses == nil {
+		statuses = defaultRetryStatuses
+	}
+	if !intInSlice(resp.StatusCode, statuses) {
+		return false
+	}
+	return p.RetryNonIdempotent || isIdempotent(ctx, method)
+}
+
+// backoff returns how long to wait before the next attempt. Retry-After is honored when present, otherwise the delay
+// grows exponentially from MinBackoff with full jitter.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return wait
+		}
+	}
+
+	ceiling := p.MinBackoff << uint(attempt-1)
+	if ceiling <= 0 || (p.MaxBackoff > 0 && ceiling > p.MaxBackoff) {
+		ceiling = p.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling))) + 1
+}
+
+// retryAfter parses a Retry-After header, which may either be a number of seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		wait := time.Until(when)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+type idempotentKey struct{}
+
+// withIdempotent marks the requests made with ctx as safe to retry whatever their method. It is used for POST
+// endpoints that only read data, such as Tenable.sc analysis queries.
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(ctx context.Context, method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	marked, _ := ctx.Value(idempotentKey{}).(bool)
+	return marked
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drainAndClose discards what is left of a response body so the underlying connection can be reused.
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+}
@@ -0,0 +1,225 @@
+package go_tenable_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+)
+
+// retryWaitLogger records the wait of the first retry and then cancels the request, so a test can read the backoff
+// without sleeping through it.
+type retryWaitLogger struct {
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	waits []time.Duration
+}
+
+func (l *retryWaitLogger) Debug(string, ...interface{}) {}
+func (l *retryWaitLogger) Info(string, ...interface{})  {}
+func (l *retryWaitLogger) Error(string, ...interface{}) {}
+
+func (l *retryWaitLogger) Warn(msg string, keysAndValues ...interface{}) {
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if keysAndValues[i] == "wait" {
+			l.mu.Lock()
+			l.waits = append(l.waits, keysAndValues[i+1].(time.Duration))
+			l.mu.Unlock()
+		}
+	}
+	l.cancel()
+}
+
+// statusServer answers every request with status and the given Retry-After header, counting the requests.
+func statusServer(status int, retryAfter string) (*httptest.Server, func() int) {
+	var mu sync.Mutex
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(status)
+	}))
+	return srv, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+// fastRetries retries without waiting noticeably.
+var fastRetries = go_tenable.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func TestRetryAfterSetsTheBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		retryAfter string
+		maxBackoff time.Duration
+		min, max   time.Duration
+	}{
+		{"seconds", "7", 0, 7 * time.Second, 7 * time.Second},
+		{"HTTP date", time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat), 0, 3 * time.Second, 5 * time.Second},
+		{"past HTTP date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, 0, 0},
+		{"capped", "3600", 2 * time.Second, 2 * time.Second, 2 * time.Second},
+		{"computed backoff capped", "", 3 * time.Second, time.Nanosecond, 3 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, _ := statusServer(http.StatusServiceUnavailable, tc.retryAfter)
+			defer srv.Close()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			logger := &retryWaitLogger{cancel: cancel}
+			policy := go_tenable.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Hour, MaxBackoff: tc.maxBackoff}
+			if tc.retryAfter != "" {
+				policy.MinBackoff = time.Millisecond
+			}
+			tio, err := go_tenable.NewTenableIO("access", "secret", go_tenable.WithBaseURL(srv.URL),
+				go_tenable.WithRetryPolicy(policy), go_tenable.WithLogger(logger))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = tio.GetWithContext(ctx, "scans", ""); !errors.Is(err, context.Canceled) {
+				t.Fatalf("got error %v, want the cancellation during the backoff", err)
+			}
+			if len(logger.waits) != 1 {
+				t.Fatalf("got waits %v, want one retry", logger.waits)
+			}
+			if wait := logger.waits[0]; wait < tc.min || wait > tc.max {
+				t.Errorf("waited %v, want between %v and %v", wait, tc.min, tc.max)
+			}
+		})
+	}
+}
+
+func TestRetryCancellationDuringBackoffReturnsPromptly(t *testing.T) {
+	srv, requests := statusServer(http.StatusTooManyRequests, "3600")
+	defer srv.Close()
+	tio, err := go_tenable.NewTenableIO("access", "secret", go_tenable.WithBaseURL(srv.URL),
+		go_tenable.WithRetryPolicy(go_tenable.RetryPolicy{MaxAttempts: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = tio.GetWithContext(ctx, "scans", "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want the context deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %v", elapsed)
+	}
+	if got := requests(); got != 1 {
+		t.Errorf("sent %v requests, want 1", got)
+	}
+}
+
+func TestRetryNonIdempotentRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		policy go_tenable.RetryPolicy
+		method string
+		want   int
+	}{
+		{"GET after 503", http.StatusServiceUnavailable, fastRetries, "GET", 3},
+		{"POST after 503", http.StatusServiceUnavailable, fastRetries, "POST", 1},
+		{"POST after 503 with RetryNonIdempotent", http.StatusServiceUnavailable, withNonIdempotent(fastRetries), "POST", 3},
+		{"POST after 429", http.StatusTooManyRequests, fastRetries, "POST", 3},
+		{"POST after 429 missing from RetryStatuses", http.StatusTooManyRequests, withStatuses(fastRetries, 503), "POST", 3},
+		{"GET after 404", http.StatusNotFound, fastRetries, "GET", 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, requests := statusServer(tc.status, "")
+			defer srv.Close()
+			tio, err := go_tenable.NewTenableIO("access", "secret", go_tenable.WithBaseURL(srv.URL),
+				go_tenable.WithRetryPolicy(tc.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resp *http.Response
+			if tc.method == "GET" {
+				resp, err = tio.Get("scans", "")
+			} else {
+				resp, err = tio.Post("scans", []byte(`{}`))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if got := requests(); got != tc.want {
+				t.Errorf("sent %v requests, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func withNonIdempotent(policy go_tenable.RetryPolicy) go_tenable.RetryPolicy {
+	policy.RetryNonIdempotent = true
+	return policy
+}
+
+func withStatuses(policy go_tenable.RetryPolicy, statuses ...int) go_tenable.RetryPolicy {
+	policy.RetryStatuses = statuses
+	return policy
+}
+
+// failingTransport fails every request with a connection error.
+type failingTransport struct {
+	mu       sync.Mutex
+	requests int
+}
+
+func (f *failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestRetryConnectionErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy go_tenable.RetryPolicy
+		method string
+		want   int
+	}{
+		{"GET", fastRetries, "GET", 3},
+		{"POST", fastRetries, "POST", 1},
+		{"POST with RetryNonIdempotent", withNonIdempotent(fastRetries), "POST", 3},
+		{"read-only POST", fastRetries, "analysis", 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := &failingTransport{}
+			sc, err := go_tenable.NewTenableSC("", go_tenable.WithBaseURL("https://sc.invalid/rest"),
+				go_tenable.WithRoundTripper(transport), go_tenable.WithRetryPolicy(tc.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Every request fails, so only the number of attempts matters.
+			switch tc.method {
+			case "GET":
+				_, _ = sc.Get("asset", "")
+			case "POST":
+				_, _ = sc.Post("asset", []byte(`{}`))
+			case "analysis":
+				// Analysis queries only read data, so they are marked safe to retry.
+				_ = sc.Analysis(context.Background(), go_tenable.AnalysisQuery{Type: "vuln"}, 10).Next()
+			}
+			if transport.requests != tc.want {
+				t.Errorf("sent %v requests, want %v", transport.requests, tc.want)
+			}
+		})
+	}
+}
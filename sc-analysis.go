@@ -0,0 +1,202 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// QueryAnalysis runs an analysis query and returns the records between startOffset and endOffset.
+func (sc *TenableSC) QueryAnalysis(query AnalysisQuery, startOffset int, endOffset int) (AnalysisResponse, error) {
+	return sc.QueryAnalysisWithContext(context.Background(), query, startOffset, endOffset)
+}
+
+func (sc *TenableSC) QueryAnalysisWithContext(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int) (analysisResponse AnalysisResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.QueryAnalysis", "analysis")
+	defer func() { endSpan(span, err) }()
+
+	var results []json.RawMessage
+	analysisResponse, err = sc.streamAnalysis(ctx, query, startOffset, endOffset, func(record json.RawMessage) error {
+		results = append(results, record)
+		return nil
+	})
+	analysisResponse.Response.Results = results
+	return analysisResponse, err
+}
+
+func (sc *TenableSC) StreamAnalysis(query AnalysisQuery, startOffset int, endOffset int, fn func(json.RawMessage) error) (AnalysisResponse, error) {
+	return sc.StreamAnalysisWithContext(context.Background(), query, startOffset, endOffset, fn)
+}
+
+// StreamAnalysisWithContext runs the query and calls fn for each record as it is decoded instead of collecting them,
+// returning the rest of the response with Results left empty. Iteration stops at the first error returned by fn,
+// which is returned as is.
+func (sc *TenableSC) StreamAnalysisWithContext(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int, fn func(json.RawMessage) error) (analysisResponse AnalysisResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.StreamAnalysis", "analysis")
+	defer func() { endSpan(span, err) }()
+
+	return sc.streamAnalysis(ctx, query, startOffset, endOffset, func(record json.RawMessage) error {
+		if err := fn(record); err != nil {
+			return callbackError{err}
+		}
+		return nil
+	})
+}
+
+func (sc *TenableSC) streamAnalysis(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int, fn func(json.RawMessage) error) (AnalysisResponse, error) {
+	var analysisResponse AnalysisResponse
+	payload := query.toRequest(startOffset, endOffset)
+	bPayload, err := json.Marshal(payload)
+	if err != nil {
+		return analysisResponse, err
+	}
+
+	// The analysis endpoint only reads data, so it is safe to retry despite being a POST
+	resp, err := sc.PostWithContext(withIdempotent(ctx), "analysis", bPayload)
+	if err != nil {
+		return analysisResponse, err
+	}
+	page := &analysisResponse.Response
+	var env scEnvelope
+	err = streamSCResponse(resp, &env, func(dec *json.Decoder) error {
+		return forEachField(dec, func(key string, dec *json.Decoder) error {
+			switch key {
+			case "totalRecords":
+				return dec.Decode(&page.TotalRecords)
+			case "returnedRecords":
+				return dec.Decode(&page.ReturnedRecords)
+			case "startOffset":
+				return dec.Decode(&page.StartOffset)
+			case "endOffset":
+				return dec.Decode(&page.EndOffset)
+			case "results":
+				return forEachElement(dec, func(dec *json.Decoder) error {
+					var record json.RawMessage
+					if err := dec.Decode(&record); err != nil {
+						return err
+					}
+					return fn(record)
+				})
+			default:
+				return skipValue(dec)
+			}
+		})
+	})
+	analysisResponse.Type, analysisResponse.ErrorCode, analysisResponse.ErrorMsg = env.Type, env.ErrorCode, env.ErrorMsg
+	analysisResponse.Warnings, analysisResponse.Timestamp = env.Warnings, env.Timestamp
+	return analysisResponse, err
+}
+
+// analysisPageSize is the number of records requested per page when none is given.
+const analysisPageSize = 1000
+
+// Analysis returns an iterator over every record matching query, fetching pageSize records per request. A pageSize of
+// 0 uses a default of 1000.
+func (sc *TenableSC) Analysis(ctx context.Context, query AnalysisQuery, pageSize int) *AnalysisIterator {
+	if pageSize <= 0 {
+		pageSize = analysisPageSize
+	}
+	return &AnalysisIterator{newIterator(ctx, pageSize,
+		func(ctx context.Context, offset int, limit int, _ interface{}) ([]interface{}, int, error) {
+			page, err := sc.QueryAnalysisWithContext(ctx, query, offset, offset+limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			total, err := strconv.Atoi(page.Response.TotalRecords)
+			if err != nil {
+				total = -1
+			}
+			items := make([]interface{}, len(page.Response.Results))
+			for i, result := range page.Response.Results {
+				items[i] = result
+			}
+			return items, total, nil
+		})}
+}
+
+// AnalysisIterator streams analysis records one at a time. The shape of each record depends on the query tool, so
+// records are returned as raw JSON to be decoded by the caller. See Iterator for usage.
+type AnalysisIterator struct {
+	*Iterator
+}
+
+// Value returns the record Next advanced to.
+func (it *AnalysisIterator) Value() json.RawMessage {
+	record, _ := it.Iterator.Value().(json.RawMessage)
+	return record
+}
+
+// Decode unmarshals the record Next advanced to into v.
+func (it *AnalysisIterator) Decode(v interface{}) error {
+	return json.Unmarshal(it.Value(), v)
+}
+
+// ForEach calls fn for every remaining record, stopping at the first error.
+func (it *AnalysisIterator) ForEach(fn func(json.RawMessage) error) error {
+	return it.forEach(func(v interface{}) error {
+		return fn(v.(json.RawMessage))
+	})
+}
+
+// AnalysisQuery describes a Tenable.sc analysis request, e.g. Type "vuln", SourceType "cumulative" and Tool
+// "vulndetails".
+type AnalysisQuery struct {
+	Type       string
+	SourceType string
+	Tool       string
+	Filters    []AnalysisFilter
+	SortField  string
+	SortDir    string
+}
+
+type AnalysisFilter struct {
+	FilterName string      `json:"filterName"`
+	Operator   string      `json:"operator"`
+	Value      interface{} `json:"value"`
+}
+
+type analysisRequest struct {
+	Type       string `json:"type"`
+	SourceType string `json:"sourceType"`
+	SortField  string `json:"sortField,omitempty"`
+	SortDir    string `json:"sortDir,omitempty"`
+	Query      struct {
+		Type        string           `json:"type"`
+		Tool        string           `json:"tool"`
+		Filters     []AnalysisFilter `json:"filters"`
+		StartOffset int              `json:"startOffset"`
+		EndOffset   int              `json:"endOffset"`
+	} `json:"query"`
+}
+
+func (query AnalysisQuery) toRequest(startOffset int, endOffset int) analysisRequest {
+	var req analysisRequest
+	req.Type = query.Type
+	req.SourceType = query.SourceType
+	req.SortField = query.SortField
+	req.SortDir = query.SortDir
+	req.Query.Type = query.Type
+	req.Query.Tool = query.Tool
+	req.Query.Filters = query.Filters
+	if req.Query.Filters == nil {
+		req.Query.Filters = []AnalysisFilter{}
+	}
+	req.Query.StartOffset = startOffset
+	req.Query.EndOffset = endOffset
+	return req
+}
+
+type AnalysisResponse struct {
+	Type     string `json:"type"`
+	Response struct {
+		TotalRecords    string            `json:"totalRecords"`
+		ReturnedRecords int               `json:"returnedRecords"`
+		StartOffset     string            `json:"startOffset"`
+		EndOffset       string            `json:"endOffset"`
+		Results         []json.RawMessage `json:"results"`
+	} `json:"response"`
+	ErrorCode int           `json:"error_code"`
+	ErrorMsg  string        `json:"error_msg"`
+	Warnings  []interface{} `json:"warnings"`
+	Timestamp int           `json:"timestamp"`
+}
@@ -1,27 +1,45 @@
 package go_tenable
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"net/http"
 	"strconv"
 )
 
-func (sc *TenableSC) ListAssets() AssetListResponse {
-	resp, err := sc.Get("asset", "canUse,canManage,owner,groups,ownerGroup,status,name,type,"+
-		"description,createdTime,modifiedTime,ipCount,repositories,tags,creator,targetGroup,template")
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	var Assets = AssetListResponse{}
-	err = json.Unmarshal(tmp, &Assets)
+func (sc *TenableSC) ListAssets() (AssetListResponse, error) {
+	return sc.ListAssetsWithContext(context.Background())
+}
+
+func (sc *TenableSC) ListAssetsWithContext(ctx context.Context) (Assets AssetListResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.ListAssets", "asset")
+	defer func() { endSpan(span, err) }()
 
+	resp, err := sc.GetWithContext(ctx, "asset", "fields=canUse,canManage,owner,groups,ownerGroup,status,name,type,"+
+		"description,createdTime,modifiedTime,ipCount,repositories,tags,creator,targetGroup,template")
 	if err != nil {
-		log.Printf("Unable to unmarshal Asset Response: %v", err)
+		return Assets, err
 	}
-
-	return Assets
-
+	var env scEnvelope
+	err = streamSCResponse(resp, &env, func(dec *json.Decoder) error {
+		return forEachField(dec, func(key string, dec *json.Decoder) error {
+			if key != "usable" {
+				return skipValue(dec)
+			}
+			return forEachElement(dec, func(dec *json.Decoder) error {
+				var asset AssetResponse
+				if err := dec.Decode(&asset); err != nil {
+					return err
+				}
+				Assets.Response.Usable = append(Assets.Response.Usable, asset)
+				return nil
+			})
+		})
+	})
+	Assets.Type, Assets.ErrorCode, Assets.ErrorMsg, Assets.Warnings, Assets.Timestamp = env.Type, env.ErrorCode,
+		env.ErrorMsg, env.Warnings, env.Timestamp
+	return Assets, err
 }
 
 type AssetResponse struct {
@@ -87,6 +105,9 @@ type AssetListResponse struct {
 
 // Static IP Asset
 
+// ErrMissingAssetID is returned by StaticIPAsset methods that require the asset to have been created or viewed first.
+var ErrMissingAssetID = errors.New("no ID set on asset; unable to act on an asset without its unique identifier")
+
 func (sc TenableSC) NewStaticIPAsset(Name string, IPAddresses string, Description string, Tag string) StaticIPAsset {
 	asset := StaticIPAsset{
 		ID:          0,
@@ -99,6 +120,13 @@ func (sc TenableSC) NewStaticIPAsset(Name string, IPAddresses string, Descriptio
 	return asset
 }
 
+// NewStaticIPAssetService is NewStaticIPAsset behind the SCStaticIPAssetService interface, for code that manages
+// assets through TenableSCService and can be handed a FakeTenableSC.
+func (sc TenableSC) NewStaticIPAssetService(Name string, IPAddresses string, Description string, Tag string) SCStaticIPAssetService {
+	asset := sc.NewStaticIPAsset(Name, IPAddresses, Description, Tag)
+	return &asset
+}
+
 type StaticIPAsset struct {
 	ID          int
 	Name        string
@@ -108,8 +136,23 @@ type StaticIPAsset struct {
 	Tag         string
 }
 
-func (asset *StaticIPAsset) Create(sc TenableSC) StaticIPCreateResponse {
-	log.Printf("Creating %v Asset %v\n", asset.Type, asset.Name)
+// Asset returns a copy of the asset, including the ID set by Create or View.
+func (asset *StaticIPAsset) Asset() StaticIPAsset {
+	return *asset
+}
+
+func (asset *StaticIPAsset) Create(sc TenableSC) (StaticIPCreateResponse, error) {
+	return asset.CreateWithContext(context.Background(), sc)
+}
+
+func (asset *StaticIPAsset) CreateWithContext(ctx context.Context, sc TenableSC) (createResponse StaticIPCreateResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "StaticIPAsset.Create", "asset")
+	defer func() {
+		span.SetAttributes(Attribute{Key: AttrAssetID, Value: asset.ID})
+		endSpan(span, err)
+	}()
+
+	sc.BaseClient.logger().Info("Creating asset", "type", asset.Type, "name", asset.Name)
 	payload := make(map[string]string)
 	payload["name"] = asset.Name
 	payload["definedIPs"] = asset.DefinedIPs
@@ -119,20 +162,16 @@ func (asset *StaticIPAsset) Create(sc TenableSC) StaticIPCreateResponse {
 
 	bPayload, _ := json.Marshal(payload)
 
-	resp, err := sc.Post("asset", bPayload)
+	resp, err := sc.PostWithContext(ctx, "asset", bPayload)
 	if err != nil {
-		log.Printf("Unable to make asset creation request: %v\n", err)
+		return createResponse, err
 	}
-	var createResponse StaticIPCreateResponse
-	bBody, _ := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode == 200 {
-		err = json.Unmarshal(bBody, &createResponse)
-		asset.ID, _ = strconv.Atoi(createResponse.Response.ID)
-	} else {
-		log.Printf("Failed to create Asset: %v\n", string(bBody))
+	if err = decodeSCResponse(resp, &createResponse); err != nil {
+		return createResponse, err
 	}
+	asset.ID, _ = strconv.Atoi(createResponse.Response.ID)
 
-	return createResponse
+	return createResponse, nil
 }
 
 type StaticIPCreateResponse struct {
@@ -197,11 +236,18 @@ type StaticIPCreateResponse struct {
 	Timestamp int           `json:"timestamp"`
 }
 
-func (asset StaticIPAsset) Edit(sc TenableSC) *http.Response {
-	log.Printf("Updating Asset %v (%v)\n", asset.Name, asset.ID)
+func (asset StaticIPAsset) Edit(sc TenableSC) error {
+	return asset.EditWithContext(context.Background(), sc)
+}
+
+func (asset StaticIPAsset) EditWithContext(ctx context.Context, sc TenableSC) (err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "StaticIPAsset.Edit", "asset/{id}",
+		Attribute{Key: AttrAssetID, Value: asset.ID})
+	defer func() { endSpan(span, err) }()
+
+	sc.BaseClient.logger().Info("Updating asset", "name", asset.Name, "id", asset.ID)
 	if asset.ID == 0 {
-		log.Printf("No ID set in struct. Unable to update Asset without its unique identifier\n")
-		return nil
+		return ErrMissingAssetID
 	}
 
 	tmpPayload := make(map[string]string)
@@ -224,26 +270,37 @@ func (asset StaticIPAsset) Edit(sc TenableSC) *http.Response {
 
 	bPayload, _ := json.Marshal(tmpPayload)
 
-	resp, _ := sc.Patch(fmt.Sprintf("asset/%v", asset.ID), bPayload)
-
-	if resp.StatusCode == 200 {
-		log.Printf("Successfully updated asset %v (%v)\n", asset.Name, asset.ID)
-	} else {
-		bBody, _ := ioutil.ReadAll(resp.Body)
-		log.Printf("Error updating asset: %v\n", bBody)
+	resp, err := sc.PatchWithContext(ctx, fmt.Sprintf("asset/%v", asset.ID), bPayload)
+	if err != nil {
+		return err
 	}
-	return resp
+	if err = decodeSCResponse(resp, nil); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (asset *StaticIPAsset) View(sc TenableSC) {
-	log.Printf("Fetching latest information on Asset %v (%v)\n", asset.Name, asset.ID)
+func (asset *StaticIPAsset) View(sc TenableSC) error {
+	return asset.ViewWithContext(context.Background(), sc)
+}
 
-	resp, _ := sc.Get(fmt.Sprintf("asset/%v", asset.ID), "id,name,description,typeFields,tags")
-	var viewRes StaticIPViewResponse
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	err := json.Unmarshal(tmp, &viewRes)
+func (asset *StaticIPAsset) ViewWithContext(ctx context.Context, sc TenableSC) (err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "StaticIPAsset.View", "asset/{id}",
+		Attribute{Key: AttrAssetID, Value: asset.ID})
+	defer func() { endSpan(span, err) }()
+
+	sc.BaseClient.logger().Debug("Fetching latest information on asset", "name", asset.Name, "id", asset.ID)
+	if asset.ID == 0 {
+		return ErrMissingAssetID
+	}
+
+	resp, err := sc.GetWithContext(ctx, fmt.Sprintf("asset/%v", asset.ID), "fields=id,name,description,typeFields,tags")
 	if err != nil {
-		log.Printf("Unable to unmarshal Static IP view response: %v\n", err)
+		return err
+	}
+	var viewRes StaticIPViewResponse
+	if err = decodeSCResponse(resp, &viewRes); err != nil {
+		return err
 	}
 
 	asset.ID, _ = strconv.Atoi(viewRes.Response.ID)
@@ -251,6 +308,7 @@ func (asset *StaticIPAsset) View(sc TenableSC) {
 	asset.Description = viewRes.Response.Description
 	asset.DefinedIPs = viewRes.Response.TypeFields.DefinedIPs
 	asset.Tag = viewRes.Response.Tags
+	return nil
 }
 
 type StaticIPViewResponse struct {
@@ -270,31 +328,31 @@ type StaticIPViewResponse struct {
 	Timestamp int           `json:"timestamp"`
 }
 
-func (asset StaticIPAsset) Calculating(sc TenableSC) bool {
-	log.Printf("Fetching info for Asset %v (%v)", asset.Name, asset.ID)
+func (asset StaticIPAsset) Calculating(sc TenableSC) (bool, error) {
+	return asset.CalculatingWithContext(context.Background(), sc)
+}
+
+func (asset StaticIPAsset) CalculatingWithContext(ctx context.Context, sc TenableSC) (calculating bool, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "StaticIPAsset.Calculating", "asset/{id}",
+		Attribute{Key: AttrAssetID, Value: asset.ID})
+	defer func() { endSpan(span, err) }()
+
+	sc.BaseClient.logger().Debug("Fetching calculation status for asset", "name", asset.Name, "id", asset.ID)
 	if asset.ID == 0 {
-		log.Printf("No ID set in struct. Unable to get Asset info without its unique identifier")
-		return false
+		return false, ErrMissingAssetID
 	}
 
-	resp, _ := sc.Get(fmt.Sprintf("asset/%v", asset.ID), "id,name,ipCount")
-	var statusResponse AssetStatusResponse
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	err := json.Unmarshal(tmp, &statusResponse)
-
+	resp, err := sc.GetWithContext(ctx, fmt.Sprintf("asset/%v", asset.ID), "fields=id,name,ipCount")
 	if err != nil {
-		log.Printf("Unable to fetch calculation status for Asset %v (%v): %v", asset.Name, asset.ID, err)
+		return false, err
 	}
-
-	var ret bool
-
-	if statusResponse.Response.IPCount == -1 {
-		ret = true
-	} else {
-		ret = false
+	var statusResponse AssetStatusResponse
+	if err = decodeSCResponse(resp, &statusResponse); err != nil {
+		return false, err
 	}
 
-	return ret
+	// SC reports an ipCount of -1 while the asset is still being calculated
+	return fmt.Sprintf("%v", statusResponse.Response.IPCount) == "-1", nil
 }
 
 type AssetStatusResponse struct {
@@ -310,15 +368,25 @@ type AssetStatusResponse struct {
 	Timestamp int           `json:"timestamp"`
 }
 
-func (asset StaticIPAsset) Delete(sc TenableSC) *http.Response {
-	log.Printf("Deleting Asset %v (%v)\n", asset.Name, asset.ID)
+func (asset StaticIPAsset) Delete(sc TenableSC) error {
+	return asset.DeleteWithContext(context.Background(), sc)
+}
+
+func (asset StaticIPAsset) DeleteWithContext(ctx context.Context, sc TenableSC) (err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "StaticIPAsset.Delete", "asset/{id}",
+		Attribute{Key: AttrAssetID, Value: asset.ID})
+	defer func() { endSpan(span, err) }()
+
+	sc.BaseClient.logger().Info("Deleting asset", "name", asset.Name, "id", asset.ID)
 	if asset.ID == 0 {
-		log.Printf("No ID set in struct. Unable to delete Asset without its unique identifier\n")
-		return nil
+		return ErrMissingAssetID
 	}
 
-	resp, _ := sc.Delete(fmt.Sprintf("asset/%v", asset.ID), "")
-	return resp
+	resp, err := sc.DeleteWithContext(ctx, fmt.Sprintf("asset/%v", asset.ID), "")
+	if err != nil {
+		return err
+	}
+	return decodeSCResponse(resp, nil)
 }
 
 //// DNS Asset
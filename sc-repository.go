@@ -1,62 +1,77 @@
 package go_tenable
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 )
 
-func (sc *TenableSC) ListRepositories() RepoListResponse {
+func (sc *TenableSC) ListRepositories() (RepoListResponse, error) {
+	return sc.ListRepositoriesWithContext(context.Background())
+}
+
+func (sc *TenableSC) ListRepositoriesWithContext(ctx context.Context) (RepoList RepoListResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.ListRepositories", "repository")
+	defer func() { endSpan(span, err) }()
+
 	var params = "fields=id,name"
-	resp, err := sc.Get("repository", params)
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	var RepoList = RepoListResponse{}
-	err = json.Unmarshal(tmp, &RepoList)
+	resp, err := sc.GetWithContext(ctx, "repository", params)
 	if err != nil {
-		fmt.Printf("Unable to unmarshal the list of Repositories: %v\n", err)
+		return RepoList, err
 	}
-
-	return RepoList
+	var env scEnvelope
+	err = streamSCResponse(resp, &env, func(dec *json.Decoder) error {
+		return decodeRepoList(dec, &RepoList.Repos)
+	})
+	RepoList.Type, RepoList.Error_code, RepoList.Error_msg, RepoList.Warnings, RepoList.Timestamp = env.Type,
+		env.ErrorCode, env.ErrorMsg, env.Warnings, env.Timestamp
+	return RepoList, err
 }
 
-
-
-func (sc *TenableSC) RepositoryDetail(RepoId int) RepoDetailResponse {
-        var params = "fields=fields=name,description,type,dataFormat,organizations,createdTime,modifiedTime,vulnCount," + 
-                             "running,lastSyncTime,lastVulnUpdate,typeFields,correlation"
-        resp, err := sc.Get(fmt.Sprintf("repository/%s",RepoId), params)
-        tmp, _ := ioutil.ReadAll(resp.Body)
-        var RepoDetail = RepoDetailResponse{}
-        err = json.Unmarshal(tmp, &RepoDetail)
-        if err != nil {
-                fmt.Printf("Unable to unmarshal the list of Repositories: %v\n", err)
-        }
-
-        return RepoDetail
+// decodeRepoList decodes the repositories of a list response one at a time.
+func decodeRepoList(dec *json.Decoder, repos *[]RepoList) error {
+	return forEachElement(dec, func(dec *json.Decoder) error {
+		var repo RepoList
+		if err := dec.Decode(&repo); err != nil {
+			return err
+		}
+		*repos = append(*repos, repo)
+		return nil
+	})
 }
 
+func (sc *TenableSC) RepositoryDetail(RepoId int) (RepoDetailResponse, error) {
+	return sc.RepositoryDetailWithContext(context.Background(), RepoId)
+}
 
+func (sc *TenableSC) RepositoryDetailWithContext(ctx context.Context, RepoId int) (RepoDetail RepoDetailResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.RepositoryDetail", "repository/{id}")
+	defer func() { endSpan(span, err) }()
 
+	var params = "fields=name,description,type,dataFormat,organizations,createdTime,modifiedTime,vulnCount," +
+		"running,lastSyncTime,lastVulnUpdate,typeFields,correlation"
+	resp, err := sc.GetWithContext(ctx, fmt.Sprintf("repository/%v", RepoId), params)
+	if err != nil {
+		return RepoDetail, err
+	}
+	err = decodeSCResponse(resp, &RepoDetail)
+	return RepoDetail, err
+}
 
 type RepoList struct {
 	Name string `json:"name"`
-	Id string `json:"id"`
+	Id   string `json:"id"`
 }
 
-
-
 type RepoListResponse struct {
-	Type   string    `json:"type"`
-	Repos []RepoList `json:"response"`
-	Error_code int   `json:"error_code"`
-	Error_msg string `json:"error_msg"`
-	Warnings  []interface{} `json:"warnings"`
-	Timestamp int           `json:"timestamp"`
+	Type       string        `json:"type"`
+	Repos      []RepoList    `json:"response"`
+	Error_code int           `json:"error_code"`
+	Error_msg  string        `json:"error_msg"`
+	Warnings   []interface{} `json:"warnings"`
+	Timestamp  int           `json:"timestamp"`
 }
 
-
-
-
 type RepoDetailResponse struct {
 	Type     string `json:"type"`
 	Response struct {
@@ -80,25 +95,22 @@ type RepoDetailResponse struct {
 		} `json:"organizations"`
 		TypeFields struct {
 			NessusSchedule struct {
-			Type       string `json:"type"`
-			Start      string `json:"start"`
-			RepeatRule string `json:"repeatRule"`
-		} `json:"nessusSchedule"`
-	Correlation            []interface{} `json:"correlation"`
-	IPRange                string        `json:"ipRange"`
-	IPCount                string        `json:"ipCount"`
-	RunningNessus          string        `json:"runningNessus"`
-	LastGenerateNessusTime string        `json:"lastGenerateNessusTime"`
-	TrendingDays           string        `json:"trendingDays"`
-	TrendWithRaw           string        `json:"trendWithRaw"`
-	LastTrendUpdate        string        `json:"lastTrendUpdate"`
-} `json:"typeFields"`
-} `json:"response"`
+				Type       string `json:"type"`
+				Start      string `json:"start"`
+				RepeatRule string `json:"repeatRule"`
+			} `json:"nessusSchedule"`
+			Correlation            []interface{} `json:"correlation"`
+			IPRange                string        `json:"ipRange"`
+			IPCount                string        `json:"ipCount"`
+			RunningNessus          string        `json:"runningNessus"`
+			LastGenerateNessusTime string        `json:"lastGenerateNessusTime"`
+			TrendingDays           string        `json:"trendingDays"`
+			TrendWithRaw           string        `json:"trendWithRaw"`
+			LastTrendUpdate        string        `json:"lastTrendUpdate"`
+		} `json:"typeFields"`
+	} `json:"response"`
 	ErrorCode int           `json:"error_code"`
 	ErrorMsg  string        `json:"error_msg"`
 	Warnings  []interface{} `json:"warnings"`
 	Timestamp int           `json:"timestamp"`
 }
-
-
-
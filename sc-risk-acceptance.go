@@ -1,23 +1,38 @@
 package go_tenable
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"io/ioutil"
 )
 
-func (sc *TenableSC) ListRiskAcceptanceRules() AcceptRiskRuleResponse {
+func (sc *TenableSC) ListRiskAcceptanceRules() (AcceptRiskRuleResponse, error) {
+	return sc.ListRiskAcceptanceRulesWithContext(context.Background())
+}
+
+func (sc *TenableSC) ListRiskAcceptanceRulesWithContext(ctx context.Context) (Rules AcceptRiskRuleResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.ListRiskAcceptanceRules", "acceptRiskRule")
+	defer func() { endSpan(span, err) }()
+
 	var params = "fields=id,repository,organization,user,plugin,hostType,hostValue,port,protocol,expires,status," +
 		"comments,createdTime,modifiedTime"
-	resp, err := sc.Get("acceptRiskRule", params)
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	var Rules = AcceptRiskRuleResponse{}
-	err = json.Unmarshal(tmp, &Rules)
+	resp, err := sc.GetWithContext(ctx, "acceptRiskRule", params)
 	if err != nil {
-		fmt.Printf("Unable to unmarshal Risk Acceptance Rules: %v\n", err)
+		return Rules, err
 	}
-
-	return Rules
+	var env scEnvelope
+	err = streamSCResponse(resp, &env, func(dec *json.Decoder) error {
+		return forEachElement(dec, func(dec *json.Decoder) error {
+			var rule AcceptRiskRule
+			if err := dec.Decode(&rule); err != nil {
+				return err
+			}
+			Rules.AcceptRiskRules = append(Rules.AcceptRiskRules, rule)
+			return nil
+		})
+	})
+	Rules.Type, Rules.ErrorCode, Rules.ErrorMsg, Rules.Warnings, Rules.Timestamp = env.Type, env.ErrorCode,
+		env.ErrorMsg, env.Warnings, env.Timestamp
+	return Rules, err
 }
 
 type AcceptRiskRuleResponse struct {
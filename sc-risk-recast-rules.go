@@ -1,25 +1,38 @@
 package go_tenable
 
 import (
+	"context"
 	"encoding/json"
-	"io/ioutil"
-	"log"
 )
 
-func (sc *TenableSC) ListRiskRecastRules() RecastRiskRuleResponse {
-	var params = "fields=id,repository,organization,user,plugin,newSeverity,hostType,hostValue,port,protocol,order,status," +
-		"comments,createdTime,modifiedTime"
+func (sc *TenableSC) ListRiskRecastRules() (RecastRiskRuleResponse, error) {
+	return sc.ListRiskRecastRulesWithContext(context.Background())
+}
 
-	resp, err := sc.Get("recastRiskRule", params)
-	tmp, _ := ioutil.ReadAll(resp.Body)
-	var Rules = RecastRiskRuleResponse{}
-	err = json.Unmarshal(tmp, &Rules)
+func (sc *TenableSC) ListRiskRecastRulesWithContext(ctx context.Context) (Rules RecastRiskRuleResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.ListRiskRecastRules", "recastRiskRule")
+	defer func() { endSpan(span, err) }()
 
+	var params = "fields=id,repository,organization,user,plugin,newSeverity,hostType,hostValue,port,protocol,order,status," +
+		"comments,createdTime,modifiedTime"
+	resp, err := sc.GetWithContext(ctx, "recastRiskRule", params)
 	if err != nil {
-		log.Printf("Unable to unmarshal Risk Recast Rules: %v\n", err)
+		return Rules, err
 	}
-
-	return Rules
+	var env scEnvelope
+	err = streamSCResponse(resp, &env, func(dec *json.Decoder) error {
+		return forEachElement(dec, func(dec *json.Decoder) error {
+			var rule RecastRiskRule
+			if err := dec.Decode(&rule); err != nil {
+				return err
+			}
+			Rules.RecastRules = append(Rules.RecastRules, rule)
+			return nil
+		})
+	})
+	Rules.Type, Rules.ErrorCode, Rules.ErrorMsg, Rules.Warnings, Rules.Timestamp = env.Type, env.ErrorCode,
+		env.ErrorMsg, env.Warnings, env.Timestamp
+	return Rules, err
 }
 
 type RecastRiskRuleResponse struct {
@@ -0,0 +1,140 @@
+package go_tenable
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ReauthEvent describes an automatic re-login performed after Tenable.sc rejected a request because its session had
+// expired. Err is nil when the new session was created successfully.
+type ReauthEvent struct {
+	Time       time.Time
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Err        error
+}
+
+// WithSessionRenewal makes a Tenable.sc client remember the credentials passed to Login and, when a request is
+// rejected because the session token has expired, log in again once and replay the request. onReauth, if not nil,
+// is called once per re-login attempt, from the goroutine whose request triggered it. Requests that find the session
+// already renewed by another goroutine, or that expire before Login was called, are replayed or fail without a call.
+// Two re-logins can overlap, so onReauth must be safe for concurrent use. The option has no effect on Tenable.io and
+// Nessus clients, or on Tenable.sc clients using API keys.
+func WithSessionRenewal(onReauth func(ReauthEvent)) Option {
+	return func(o *clientOptions) {
+		o.sessionRenewal = true
+		o.onReauth = onReauth
+	}
+}
+
+var errNoSessionCredentials = errors.New("session expired and no credentials are remembered; call Login first")
+
+// sessionRenewal is shared between every copy of a TenableSC client so a re-login by one goroutine is picked up by
+// the rest.
+type sessionRenewal struct {
+	mu       sync.Mutex
+	enabled  bool
+	username string
+	password string
+	onReauth func(ReauthEvent)
+}
+
+func (r *sessionRenewal) remember(username string, password string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.username = username
+	r.password = password
+}
+
+func (r *sessionRenewal) forget() {
+	r.remember("", "")
+}
+
+// renewOnExpiry sends the request and, if session renewal is enabled and Tenable.sc reports the session as expired,
+// logs in again and replays it once. When the re-login fails the original response is returned so the caller sees
+// the session error.
+func (sc TenableSC) renewOnExpiry(ctx context.Context, endpoint string, send func() (*http.Response, error)) (*http.Response, error) {
+	resp, err := send()
+	if err != nil || endpoint == "token" || sc.renewal == nil || !sc.renewal.enabled || sc.UsesAPIKeys() {
+		return resp, err
+	}
+	if !sessionExpired(resp) {
+		return resp, nil
+	}
+
+	event := ReauthEvent{
+		Time:       time.Now(),
+		Method:     resp.Request.Method,
+		Endpoint:   endpoint,
+		StatusCode: resp.StatusCode,
+	}
+	loggedIn, err := sc.reauthenticate(ctx, resp.Request.Header.Get("X-SecurityCenter"))
+	if loggedIn {
+		event.Err = err
+		sc.BaseClient.logger().Info("Renewed expired Tenable.sc session", "endpoint", endpoint, "error", err)
+		if sc.renewal.onReauth != nil {
+			sc.renewal.onReauth(event)
+		}
+	}
+	if err != nil {
+		return resp, nil
+	}
+
+	drainAndClose(resp)
+	return send()
+}
+
+// reauthenticate logs in with the remembered credentials unless another goroutine has already replaced staleToken
+// with a new session while this request was in flight. loggedIn reports whether a login was attempted.
+func (sc TenableSC) reauthenticate(ctx context.Context, staleToken string) (loggedIn bool, err error) {
+	sc.renewal.mu.Lock()
+	defer sc.renewal.mu.Unlock()
+
+	if sc.renewal.username == "" {
+		return false, errNoSessionCredentials
+	}
+	if current := sc.BaseClient.cloneHeaders().Get("X-SecurityCenter"); current != "" && current != staleToken {
+		return false, nil
+	}
+	_, err = sc.login(ctx, sc.renewal.username, sc.renewal.password)
+	return true, err
+}
+
+// sessionEnvelopeLimit bounds how much of a successful response sessionExpired inspects. Error envelopes are small,
+// so a longer body is a real result and is left to stream to the caller.
+const sessionEnvelopeLimit = 4096
+
+// sessionExpired reports whether Tenable.sc rejected the request because of its session token: HTTP 401, or an
+// error_code 74 envelope, which Tenable.sc sends with HTTP 403 and sometimes with HTTP 200. The body is buffered
+// rather than consumed, so the response can still be decoded by the caller.
+func sessionExpired(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden, http.StatusOK:
+	default:
+		return false
+	}
+
+	br := bufio.NewReaderSize(resp.Body, sessionEnvelopeLimit)
+	resp.Body = peekedBody{Reader: br, Closer: resp.Body}
+	body, err := br.Peek(sessionEnvelopeLimit)
+	if err != io.EOF {
+		return false
+	}
+	var envelope errorEnvelope
+	return json.Unmarshal(body, &envelope) == nil && envelope.ErrorCode == scErrorCodeInvalidToken
+}
+
+// peekedBody is a response body read through the buffer sessionExpired peeked into.
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,86 @@
+package go_tenable_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+// newRenewingSC returns a client with session renewal enabled and a function returning the events reported so far.
+func newRenewingSC(t *testing.T, srv *tenabletest.SCServer) (go_tenable.TenableSC, func() []go_tenable.ReauthEvent) {
+	t.Helper()
+	var mu sync.Mutex
+	var events []go_tenable.ReauthEvent
+	opts := append(srv.Options(), go_tenable.WithSessionRenewal(func(event go_tenable.ReauthEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}))
+	sc, err := go_tenable.NewTenableSC("", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sc, func() []go_tenable.ReauthEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]go_tenable.ReauthEvent(nil), events...)
+	}
+}
+
+func TestSessionRenewalReportsOneEventPerLogin(t *testing.T) {
+	for _, status := range []int{http.StatusForbidden, http.StatusOK} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+			defer srv.Close()
+			srv.SetInvalidTokenStatus(status)
+			sc, events := newRenewingSC(t, srv)
+			if _, err := sc.Login(tenabletest.SCUsername, tenabletest.SCPassword); err != nil {
+				t.Fatal(err)
+			}
+
+			srv.ExpireSessions()
+			var wg sync.WaitGroup
+			errs := make(chan error, 10)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := sc.ListAssets()
+					errs <- err
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Fatalf("ListAssets after expiry: %v", err)
+				}
+			}
+
+			got := events()
+			if len(got) != 1 {
+				t.Fatalf("got %v reauth events, want 1: %+v", len(got), got)
+			}
+			if got[0].Err != nil || got[0].StatusCode != status || got[0].Endpoint != "asset" {
+				t.Errorf("unexpected reauth event %+v", got[0])
+			}
+		})
+	}
+}
+
+func TestSessionRenewalBeforeLogin(t *testing.T) {
+	srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+	defer srv.Close()
+	sc, events := newRenewingSC(t, srv)
+
+	_, err := sc.ListAssets()
+	if !go_tenable.IsUnauthorized(err) {
+		t.Fatalf("got error %v, want an unauthorized APIError", err)
+	}
+	if got := events(); len(got) != 0 {
+		t.Fatalf("got reauth events %+v before Login", got)
+	}
+}
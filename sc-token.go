@@ -1,17 +1,35 @@
 package go_tenable
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"log"
 )
 
 // With the provided user name and password, attempts to create an authenticated session with Tenable.sc using the
 // token endpoint. The function needs to be executed prior to any other SC client request as it sets the token and
-// session cookie for requests.
+// session cookie for requests, unless the client was created with NewTenableSCWithAPIKeys. When the client was created
+// with WithSessionRenewal the credentials are remembered so an expired session can be renewed automatically.
 func (sc *TenableSC) Login(scUser string, scPassword string) (*TokenResponse, error) {
+	return sc.LoginWithContext(context.Background(), scUser, scPassword)
+}
+
+func (sc *TenableSC) LoginWithContext(ctx context.Context, scUser string, scPassword string) (tokenResponse *TokenResponse, err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.Login", "token")
+	defer func() { endSpan(span, err) }()
+
+	tokenResponse, err = sc.login(ctx, scUser, scPassword)
+	if err != nil {
+		return nil, err
+	}
+	if sc.renewal != nil && sc.renewal.enabled {
+		sc.renewal.remember(scUser, scPassword)
+	}
+	return tokenResponse, nil
+}
+
+func (sc *TenableSC) login(ctx context.Context, scUser string, scPassword string) (*TokenResponse, error) {
 	// Read in the SC username and password
 	payload := TokenRequest{
 		scUser,
@@ -19,16 +37,14 @@ func (sc *TenableSC) Login(scUser string, scPassword string) (*TokenResponse, er
 	}
 
 	// Make POST request to token endpoint
-	resp, err := sc.Post("token", payload.ToBytes())
+	resp, err := sc.PostWithContext(ctx, "token", payload.ToBytes())
 	if err != nil {
-		log.Printf("Unable to request a new token: %v\n", err)
 		return nil, err
 	}
 
-	// Unmarshal response
-	tmp, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("Unable to read login response body: %v\n", err)
+	// Unmarshal response, surfacing the SC error envelope if the login was rejected
+	var tokenResponse = TokenResponse{}
+	if err = decodeSCResponse(resp, &tokenResponse); err != nil {
 		return nil, err
 	}
 
@@ -42,46 +58,62 @@ func (sc *TenableSC) Login(scUser string, scPassword string) (*TokenResponse, er
 
 	// If no cookie was found then return error
 	if cookieFound == "" {
-		var errorResp = "Unable to find \"TNS_SESSIONID\" cookie in login response\n"
-		log.Print(errorResp)
-		return nil, errors.New(errorResp)
-	}
-
-	// Attempt to unmarshal to a successful response
-	var tokenResponse = TokenResponse{}
-	err = json.Unmarshal(tmp, &tokenResponse)
-	if err != nil {
-		// If the response is unsuccessful attempts to unmarshal to an error response
-		tokenErrorResponse := TokenError{}
-		err = json.Unmarshal(tmp, &tokenErrorResponse)
-		if err != nil {
-			// if unable to unmarshal successful or error then the request and response are FUBAR
-			log.Printf("Unable to unmarshal error response either. %v", err)
-			return nil, err
-		}
-		return nil, errors.New(fmt.Sprintf("%v", tokenErrorResponse.ErrorMsg))
+		return nil, ErrMissingSessionCookie
 	}
 
-	// At this point we should have a valid token and session cookie
-	sc.token = tokenResponse.Response.Token
-	sc.session = cookieFound
-
-	// Set the cookie session and token header on the client object
-	sc.BaseClient.Headers.Add("X-SecurityCenter", fmt.Sprintf("%v", sc.token))
-	sc.BaseClient.Headers.Add("Cookie", fmt.Sprintf("TNS_SESSIONID=%v", sc.session))
+	// At this point we should have a valid token and session cookie. Replace any headers from a previous login so
+	// repeated logins never send stacked tokens.
+	// The session lives only in the shared headers: TenableSC is copied by value on every request, so fields set here
+	// would race with those copies.
+	sc.BaseClient.lock()
+	sc.BaseClient.Headers.Set("X-SecurityCenter", fmt.Sprintf("%v", tokenResponse.Response.Token))
+	sc.BaseClient.Headers.Set("Cookie", fmt.Sprintf("TNS_SESSIONID=%v", cookieFound))
+	sc.BaseClient.unlock()
 
 	return &tokenResponse, nil
 }
 
 func (sc *TenableSC) Logout() error {
-	_, err := sc.Delete("token", "")
+	return sc.LogoutWithContext(context.Background())
+}
+
+func (sc *TenableSC) LogoutWithContext(ctx context.Context) (err error) {
+	ctx, span := sc.BaseClient.startSpan(ctx, "TenableSC.Logout", "token")
+	defer func() { endSpan(span, err) }()
+
+	// API key clients never open a session, so there is nothing to end
+	if sc.UsesAPIKeys() {
+		return nil
+	}
+
+	resp, err := sc.DeleteWithContext(ctx, "token", "")
 	if err != nil {
-		log.Printf("Unable to log out of Tenable.SC: %v\n", err)
 		return err
 	}
+	if err = decodeSCResponse(resp, nil); err != nil {
+		return err
+	}
+
+	if sc.renewal != nil {
+		sc.renewal.forget()
+	}
+	sc.BaseClient.lock()
+	sc.BaseClient.Headers.Del("X-SecurityCenter")
+	sc.BaseClient.Headers.Del("Cookie")
+	sc.BaseClient.unlock()
 	return nil
 }
 
+// UsesAPIKeys reports whether the client was created with NewTenableSCWithAPIKeys and authenticates each request with
+// the x-apikey header rather than a session token.
+func (sc *TenableSC) UsesAPIKeys() bool {
+	return sc.accessKey != ""
+}
+
+// ErrMissingSessionCookie is returned by Login when Tenable.sc accepts the credentials but does not hand back a
+// TNS_SESSIONID cookie.
+var ErrMissingSessionCookie = errors.New("unable to find \"TNS_SESSIONID\" cookie in login response")
+
 type TokenRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
@@ -121,9 +153,6 @@ type TokenCookie struct {
 }
 
 func (req TokenRequest) ToBytes() []byte {
-	ret, err := json.Marshal(req)
-	if err != nil {
-		log.Printf("Unable to marshal token request body")
-	}
+	ret, _ := json.Marshal(req)
 	return ret
 }
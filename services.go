@@ -0,0 +1,263 @@
+package go_tenable
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// The interfaces below describe the public methods of the clients so code that depends on this library can accept a
+// test double instead of a concrete client. *TenableIO, *TenableSC, *Nessus, *Export and *StaticIPAsset implement
+// them, as do the Fake types in fakes.go.
+
+// Tenable.io
+
+type IOAgentService interface {
+	ListAgents() ([]AgentResponse, error)
+	ListAgentsWithContext(ctx context.Context) ([]AgentResponse, error)
+	Agents(ctx context.Context, pageSize int) *AgentIterator
+	GetAgent(agentID int) (Agent, error)
+	GetAgentWithContext(ctx context.Context, agentID int) (Agent, error)
+	UnlinkAgent(agentID int) error
+	UnlinkAgentWithContext(ctx context.Context, agentID int) error
+	UnlinkAgents(agentIDs []int) (AgentBulkTask, error)
+	UnlinkAgentsWithContext(ctx context.Context, agentIDs []int) (AgentBulkTask, error)
+	AgentTaskStatus(task AgentBulkTask) (AgentBulkTask, error)
+	AgentTaskStatusWithContext(ctx context.Context, task AgentBulkTask) (AgentBulkTask, error)
+	WaitForAgentTask(task AgentBulkTask, pollInterval time.Duration) (AgentBulkTask, error)
+	WaitForAgentTaskWithContext(ctx context.Context, task AgentBulkTask, pollInterval time.Duration) (AgentBulkTask, error)
+	ListAgentGroups() ([]AgentGroup, error)
+	ListAgentGroupsWithContext(ctx context.Context) ([]AgentGroup, error)
+	CreateAgentGroup(name string) (AgentGroup, error)
+	CreateAgentGroupWithContext(ctx context.Context, name string) (AgentGroup, error)
+	DeleteAgentGroup(groupID int) error
+	DeleteAgentGroupWithContext(ctx context.Context, groupID int) error
+	AddAgentToGroup(groupID int, agentID int) error
+	AddAgentToGroupWithContext(ctx context.Context, groupID int, agentID int) error
+	RemoveAgentFromGroup(groupID int, agentID int) error
+	RemoveAgentFromGroupWithContext(ctx context.Context, groupID int, agentID int) error
+	AddAgentsToGroup(groupID int, agentIDs []int) (AgentBulkTask, error)
+	AddAgentsToGroupWithContext(ctx context.Context, groupID int, agentIDs []int) (AgentBulkTask, error)
+	RemoveAgentsFromGroup(groupID int, agentIDs []int) (AgentBulkTask, error)
+	RemoveAgentsFromGroupWithContext(ctx context.Context, groupID int, agentIDs []int) (AgentBulkTask, error)
+}
+
+type IOAuditLogService interface {
+	ListEvents(filter EventFilter) ([]Event, error)
+	ListEventsWithContext(ctx context.Context, filter EventFilter) ([]Event, error)
+	Events(ctx context.Context, filter EventFilter, pageSize int) *EventIterator
+}
+
+type IOScanService interface {
+	ListScans() (ScanListResponse, error)
+	ListScansWithContext(ctx context.Context) (ScanListResponse, error)
+	Scans(ctx context.Context) *ScanIterator
+}
+
+// IOExportService covers a single export created with TenableIO.NewExport.
+type IOExportService interface {
+	GetUnprocessedChunks() []int
+	RequestExport(Payload []byte) (string, error)
+	RequestExportWithContext(ctx context.Context, Payload []byte) (string, error)
+	RequestStatus() (string, error)
+	RequestStatusWithContext(ctx context.Context) (string, error)
+	DownloadChunk(ChunkID int) (AssetChunkDownloadResponse, error)
+	DownloadChunkWithContext(ctx context.Context, ChunkID int) (AssetChunkDownloadResponse, error)
+	StreamChunk(ChunkID int, fn func(ExportedAsset) error) error
+	StreamChunkWithContext(ctx context.Context, ChunkID int, fn func(ExportedAsset) error) error
+	DownloadVulnChunk(ChunkID int) ([]VulnerabilityFinding, error)
+	DownloadVulnChunkWithContext(ctx context.Context, ChunkID int) ([]VulnerabilityFinding, error)
+	StreamVulnChunk(ChunkID int, fn func(VulnerabilityFinding) error) error
+	StreamVulnChunkWithContext(ctx context.Context, ChunkID int, fn func(VulnerabilityFinding) error) error
+	DownloadComplianceChunk(ChunkID int) ([]ComplianceFinding, error)
+	DownloadComplianceChunkWithContext(ctx context.Context, ChunkID int) ([]ComplianceFinding, error)
+	StreamComplianceChunk(ChunkID int, fn func(ComplianceFinding) error) error
+	StreamComplianceChunkWithContext(ctx context.Context, ChunkID int, fn func(ComplianceFinding) error) error
+	Run(opts ExportRunOptions, fn func(ExportRecord) error) error
+	RunWithContext(ctx context.Context, opts ExportRunOptions, fn func(ExportRecord) error) error
+	Stream(opts ExportRunOptions) (<-chan ExportRecord, <-chan error)
+	StreamWithContext(ctx context.Context, opts ExportRunOptions) (<-chan ExportRecord, <-chan error)
+	State() ExportState
+	RequestStatusDetail() (ExportStatusResponse, error)
+	RequestStatusDetailWithContext(ctx context.Context) (ExportStatusResponse, error)
+	Cancel() (string, error)
+	CancelWithContext(ctx context.Context) (string, error)
+}
+
+// IOExportFactory creates exports behind IOExportService, so code that starts exports can be given fakes.
+type IOExportFactory interface {
+	NewExportService(exportType string) IOExportService
+	ResumeExportService(state ExportState) IOExportService
+}
+
+type IOExportListService interface {
+	ListExports(exportType string) ([]ExportStatusResponse, error)
+	ListExportsWithContext(ctx context.Context, exportType string) ([]ExportStatusResponse, error)
+}
+
+type IOSyncService interface {
+	SyncAssets(opts SyncOptions, fn func(ExportedAsset) error) (SyncResult, error)
+	SyncAssetsWithContext(ctx context.Context, opts SyncOptions, fn func(ExportedAsset) error) (SyncResult, error)
+	SyncVulns(opts SyncOptions, fn func(VulnerabilityFinding) error) (SyncResult, error)
+	SyncVulnsWithContext(ctx context.Context, opts SyncOptions, fn func(VulnerabilityFinding) error) (SyncResult, error)
+}
+
+type TenableIOService interface {
+	IOAgentService
+	IOAuditLogService
+	IOScanService
+	IOExportFactory
+	IOExportListService
+	IOSyncService
+}
+
+// Tenable.sc
+
+type SCSessionService interface {
+	Login(scUser string, scPassword string) (*TokenResponse, error)
+	LoginWithContext(ctx context.Context, scUser string, scPassword string) (*TokenResponse, error)
+	Logout() error
+	LogoutWithContext(ctx context.Context) error
+}
+
+type SCAssetService interface {
+	ListAssets() (AssetListResponse, error)
+	ListAssetsWithContext(ctx context.Context) (AssetListResponse, error)
+}
+
+// SCStaticIPAssetService covers a single static IP asset created with TenableSC.NewStaticIPAssetService.
+type SCStaticIPAssetService interface {
+	Asset() StaticIPAsset
+	Create(sc TenableSC) (StaticIPCreateResponse, error)
+	CreateWithContext(ctx context.Context, sc TenableSC) (StaticIPCreateResponse, error)
+	Edit(sc TenableSC) error
+	EditWithContext(ctx context.Context, sc TenableSC) error
+	View(sc TenableSC) error
+	ViewWithContext(ctx context.Context, sc TenableSC) error
+	Calculating(sc TenableSC) (bool, error)
+	CalculatingWithContext(ctx context.Context, sc TenableSC) (bool, error)
+	Delete(sc TenableSC) error
+	DeleteWithContext(ctx context.Context, sc TenableSC) error
+}
+
+// SCStaticIPAssetFactory creates static IP assets behind SCStaticIPAssetService.
+type SCStaticIPAssetFactory interface {
+	NewStaticIPAssetService(Name string, IPAddresses string, Description string, Tag string) SCStaticIPAssetService
+}
+
+type SCRepositoryService interface {
+	ListRepositories() (RepoListResponse, error)
+	ListRepositoriesWithContext(ctx context.Context) (RepoListResponse, error)
+	RepositoryDetail(RepoId int) (RepoDetailResponse, error)
+	RepositoryDetailWithContext(ctx context.Context, RepoId int) (RepoDetailResponse, error)
+}
+
+type SCRiskRuleService interface {
+	ListRiskAcceptanceRules() (AcceptRiskRuleResponse, error)
+	ListRiskAcceptanceRulesWithContext(ctx context.Context) (AcceptRiskRuleResponse, error)
+	ListRiskRecastRules() (RecastRiskRuleResponse, error)
+	ListRiskRecastRulesWithContext(ctx context.Context) (RecastRiskRuleResponse, error)
+}
+
+type SCAnalysisService interface {
+	QueryAnalysis(query AnalysisQuery, startOffset int, endOffset int) (AnalysisResponse, error)
+	QueryAnalysisWithContext(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int) (AnalysisResponse, error)
+	StreamAnalysis(query AnalysisQuery, startOffset int, endOffset int, fn func(json.RawMessage) error) (AnalysisResponse, error)
+	StreamAnalysisWithContext(ctx context.Context, query AnalysisQuery, startOffset int, endOffset int, fn func(json.RawMessage) error) (AnalysisResponse, error)
+	Analysis(ctx context.Context, query AnalysisQuery, pageSize int) *AnalysisIterator
+}
+
+type TenableSCService interface {
+	SCSessionService
+	SCAssetService
+	SCStaticIPAssetFactory
+	SCRepositoryService
+	SCRiskRuleService
+	SCAnalysisService
+}
+
+// Nessus
+
+type NessusServerService interface {
+	GetStatus() (StatusResponse, error)
+	GetStatusWithContext(ctx context.Context) (StatusResponse, error)
+	GetProperties() (PropertiesResponse, error)
+	GetPropertiesWithContext(ctx context.Context) (PropertiesResponse, error)
+}
+
+type NessusSettingsService interface {
+	GetHealthStats(count int) (ScannerSettingsResponse, error)
+	GetHealthStatsWithContext(ctx context.Context, count int) (ScannerSettingsResponse, error)
+}
+
+type NessusService interface {
+	NessusServerService
+	NessusSettingsService
+}
+
+var (
+	_ TenableIOService       = (*TenableIO)(nil)
+	_ IOExportService        = (*Export)(nil)
+	_ TenableSCService       = (*TenableSC)(nil)
+	_ SCStaticIPAssetService = (*StaticIPAsset)(nil)
+	_ NessusService          = (*Nessus)(nil)
+)
+
+// Iterators over fixed data, for test doubles
+
+// NewStaticAgentIterator returns an iterator that yields agents and then stops with err, which may be nil.
+func NewStaticAgentIterator(agents []Agent, err error) *AgentIterator {
+	items := make([]interface{}, len(agents))
+	for i, agent := range agents {
+		items[i] = agent
+	}
+	return &AgentIterator{newStaticIterator(items, err)}
+}
+
+// NewStaticEventIterator returns an iterator that yields events and then stops with err, which may be nil.
+func NewStaticEventIterator(events []Event, err error) *EventIterator {
+	items := make([]interface{}, len(events))
+	for i, event := range events {
+		items[i] = event
+	}
+	return &EventIterator{newStaticIterator(items, err)}
+}
+
+// NewStaticScanIterator returns an iterator that yields scans and then stops with err, which may be nil.
+func NewStaticScanIterator(scans []Scan, err error) *ScanIterator {
+	items := make([]interface{}, len(scans))
+	for i, scan := range scans {
+		items[i] = scan
+	}
+	return &ScanIterator{newStaticIterator(items, err)}
+}
+
+// NewStaticAnalysisIterator returns an iterator that yields records and then stops with err, which may be nil.
+func NewStaticAnalysisIterator(records []json.RawMessage, err error) *AnalysisIterator {
+	items := make([]interface{}, len(records))
+	for i, record := range records {
+		items[i] = record
+	}
+	return &AnalysisIterator{newStaticIterator(items, err)}
+}
+
+func newStaticIterator(items []interface{}, err error) *Iterator {
+	// A page shorter than the limit ends iteration, so only size the limit past the items when there is no error
+	// left to report on the following fetch
+	limit := len(items) + 1
+	if err != nil {
+		limit = len(items)
+	}
+	fetched := false
+	return newIterator(context.Background(), limit,
+		func(context.Context, int, int, interface{}) ([]interface{}, int, error) {
+			if fetched {
+				return nil, 0, err
+			}
+			fetched = true
+			if len(items) == 0 && err != nil {
+				return nil, 0, err
+			}
+			return items, -1, nil
+		})
+}
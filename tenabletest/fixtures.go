@@ -0,0 +1,227 @@
+// Package tenabletest provides in-process fakes of the Tenable.io, Tenable.sc and Nessus endpoints used by
+// go-tenable, so code built on the library can be tested end to end without a real console.
+//
+//	srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+//	defer srv.Close()
+//	sc, _ := go_tenable.NewTenableSC("", srv.Options()...)
+//	sc.Login(tenabletest.SCUsername, tenabletest.SCPassword)
+//
+// Each server is seeded from Fixtures and keeps its own state, so assets created through one client are visible to
+// later requests against the same server.
+package tenabletest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+)
+
+// Credentials accepted by servers created from DefaultFixtures.
+const (
+	SCUsername = "admin"
+	SCPassword = "password"
+	AccessKey  = "access-key"
+	SecretKey  = "secret-key"
+)
+
+// Fixtures seeds the fake servers. The JSON form uses the same field names and can be loaded with LoadFixtures.
+type Fixtures struct {
+	// Tenable.sc users (username to password) allowed to log in, and API keys accepted by the x-apikey header.
+	SCUsers           map[string]string           `json:"sc_users"`
+	SCAccessKey       string                      `json:"sc_access_key"`
+	SCSecretKey       string                      `json:"sc_secret_key"`
+	SCAssets          []go_tenable.AssetResponse  `json:"sc_assets"`
+	SCRepositories    []go_tenable.RepoList       `json:"sc_repositories"`
+	SCAcceptRiskRules []go_tenable.AcceptRiskRule `json:"sc_accept_risk_rules"`
+	SCRecastRiskRules []go_tenable.RecastRiskRule `json:"sc_recast_risk_rules"`
+	SCAnalysis        []json.RawMessage           `json:"sc_analysis"`
+
+	// Tenable.io API keys accepted by the X-ApiKeys header, and the data returned by each endpoint.
+	IOAccessKey  string                                `json:"io_access_key"`
+	IOSecretKey  string                                `json:"io_secret_key"`
+	IOAssets     go_tenable.AssetChunkDownloadResponse `json:"io_assets"`
+	IOVulns      []go_tenable.VulnerabilityFinding     `json:"io_vulns"`
+	IOCompliance []go_tenable.ComplianceFinding        `json:"io_compliance"`
+	IOAgents     []go_tenable.Agent                    `json:"io_agents"`
+	IOEvents     []go_tenable.Event                    `json:"io_events"`
+	IOScans      []go_tenable.Scan                     `json:"io_scans"`
+
+	// Nessus API keys accepted by the X-ApiKeys header, and the data returned by each endpoint.
+	NessusAccessKey  string                             `json:"nessus_access_key"`
+	NessusSecretKey  string                             `json:"nessus_secret_key"`
+	NessusStatus     go_tenable.StatusResponse          `json:"nessus_status"`
+	NessusProperties go_tenable.PropertiesResponse      `json:"nessus_properties"`
+	NessusHealth     go_tenable.ScannerSettingsResponse `json:"nessus_health"`
+}
+
+// DefaultFixtures returns a small data set that exercises every endpoint, authenticated with SCUsername/SCPassword
+// and AccessKey/SecretKey.
+func DefaultFixtures() Fixtures {
+	var f Fixtures
+	f.SCUsers = map[string]string{SCUsername: SCPassword}
+	f.SCAccessKey = AccessKey
+	f.SCSecretKey = SecretKey
+	f.IOAccessKey = AccessKey
+	f.IOSecretKey = SecretKey
+	f.NessusAccessKey = AccessKey
+	f.NessusSecretKey = SecretKey
+
+	f.SCAssets = make([]go_tenable.AssetResponse, 2)
+	f.SCAssets[0].ID = "1"
+	f.SCAssets[0].Name = "Web Servers"
+	f.SCAssets[0].Type = "static"
+	f.SCAssets[0].Status = "0"
+	f.SCAssets[1].ID = "2"
+	f.SCAssets[1].Name = "Database Servers"
+	f.SCAssets[1].Type = "static"
+	f.SCAssets[1].Status = "0"
+
+	f.SCRepositories = []go_tenable.RepoList{{Name: "Primary", Id: "1"}, {Name: "DMZ", Id: "2"}}
+
+	f.SCAcceptRiskRules = make([]go_tenable.AcceptRiskRule, 1)
+	f.SCAcceptRiskRules[0].ID = "1"
+	f.SCAcceptRiskRules[0].HostType = "all"
+	f.SCAcceptRiskRules[0].Status = "0"
+	f.SCAcceptRiskRules[0].Plugin.ID = "19506"
+	f.SCAcceptRiskRules[0].Plugin.Name = "Nessus Scan Information"
+
+	f.SCRecastRiskRules = make([]go_tenable.RecastRiskRule, 1)
+	f.SCRecastRiskRules[0].ID = "1"
+	f.SCRecastRiskRules[0].HostType = "all"
+	f.SCRecastRiskRules[0].NewSeverity = "1"
+	f.SCRecastRiskRules[0].Plugin.ID = "51192"
+	f.SCRecastRiskRules[0].Plugin.Name = "SSL Certificate Cannot Be Trusted"
+
+	for i := 1; i <= 3; i++ {
+		f.SCAnalysis = append(f.SCAnalysis, json.RawMessage(
+			`{"pluginID":"`+strconv.Itoa(19505+i)+`","ip":"10.0.0.`+strconv.Itoa(i)+`","severity":{"id":"2"}}`))
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	f.IOAssets = make(go_tenable.AssetChunkDownloadResponse, 5)
+	for i := range f.IOAssets {
+		f.IOAssets[i].ID = "00000000-0000-0000-0000-00000000000" + strconv.Itoa(i+1)
+		f.IOAssets[i].Ipv4s = []string{"10.0.1." + strconv.Itoa(i+1)}
+		f.IOAssets[i].Hostnames = []string{"host-" + strconv.Itoa(i+1)}
+		f.IOAssets[i].CreatedAt = now.Add(-time.Duration(i+1) * 24 * time.Hour)
+		f.IOAssets[i].UpdatedAt = now.Add(-time.Duration(i+1) * time.Hour)
+		f.IOAssets[i].LastSeen = f.IOAssets[i].UpdatedAt
+	}
+
+	severities := []string{"info", "low", "medium", "high", "critical"}
+	for i, asset := range f.IOAssets {
+		for j := 0; j < 2; j++ {
+			var finding go_tenable.VulnerabilityFinding
+			finding.Asset.UUID = asset.ID
+			finding.Asset.Hostname = asset.Hostnames[0]
+			finding.Asset.IPv4 = asset.Ipv4s[0]
+			finding.Plugin.ID = 19506 + j
+			finding.Plugin.Name = "Plugin " + strconv.Itoa(finding.Plugin.ID)
+			finding.Plugin.Family = "General"
+			finding.Port.Port = 443
+			finding.Port.Protocol = "TCP"
+			finding.SeverityID = (i + j) % len(severities)
+			finding.Severity = severities[finding.SeverityID]
+			finding.State = "OPEN"
+			finding.FirstFound = asset.CreatedAt
+			finding.LastFound = asset.LastSeen
+			f.IOVulns = append(f.IOVulns, finding)
+		}
+	}
+
+	results := []string{"PASSED", "FAILED", "WARNING"}
+	for i, asset := range f.IOAssets {
+		var finding go_tenable.ComplianceFinding
+		finding.AssetUUID = asset.ID
+		finding.Asset.ID = asset.ID
+		finding.Asset.Name = asset.Hostnames[0]
+		finding.Asset.IPv4Addresses = asset.Ipv4s
+		finding.PluginID = 21157
+		finding.AuditFile = "CIS_Ubuntu_Linux_22.04_LTS_v1.0.0_L1_Server.audit"
+		finding.CheckName = "1.1.1.1 Ensure mounting of cramfs filesystems is disabled"
+		finding.Status = results[i%len(results)]
+		finding.State = "OPEN"
+		finding.Reference = []go_tenable.ComplianceReference{{Framework: "800-53", Control: "CM-7"}}
+		finding.FirstSeen = asset.CreatedAt
+		finding.LastSeen = asset.LastSeen
+		f.IOCompliance = append(f.IOCompliance, finding)
+	}
+
+	for i := 1; i <= 3; i++ {
+		f.IOAgents = append(f.IOAgents, go_tenable.Agent{
+			ID:       i,
+			UUID:     "agent-" + strconv.Itoa(i),
+			Name:     "agent-" + strconv.Itoa(i),
+			Platform: "LINUX",
+			Status:   "on",
+		})
+	}
+
+	for i := 1; i <= 3; i++ {
+		event := go_tenable.Event{
+			ID:       "event-" + strconv.Itoa(i),
+			Action:   "user.authenticate.password",
+			Crud:     "r",
+			Received: now.Add(-time.Duration(i) * time.Minute),
+		}
+		event.Actor.Name = "admin@example.com"
+		f.IOEvents = append(f.IOEvents, event)
+	}
+
+	f.IOScans = []go_tenable.Scan{
+		{ID: 1, UUID: "scan-1", Name: "Weekly Network Scan", Type: "remote", Status: "completed", Enabled: true},
+		{ID: 2, UUID: "scan-2", Name: "Agent Scan", Type: "agent", Status: "running", Enabled: true},
+	}
+
+	f.NessusStatus = go_tenable.StatusResponse{Code: 200, Status: "ready"}
+	f.NessusProperties.ServerVersion = "8.10.0"
+	f.NessusProperties.License.Ips = 256
+	f.NessusHealth.PerfStatsCurrent.NessusDataDiskFree = 102400
+	f.NessusHealth.PerfStatsCurrent.Timestamp = int(now.Unix())
+	return f
+}
+
+// LoadFixtures reads fixtures from a JSON file.
+func LoadFixtures(path string) (Fixtures, error) {
+	var f Fixtures
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return f, err
+	}
+	err = json.Unmarshal(data, &f)
+	return f, err
+}
+
+// writeJSON writes v as the response body with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// pathSegments splits the request path below prefix, e.g. "/rest/asset/3" under "/rest" becomes ["asset", "3"].
+func pathSegments(r *http.Request, prefix string) []string {
+	trimmed := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+func apiKeysHeader(accessKey string, secretKey string) string {
+	return "accessKey=" + accessKey + "; secretKey=" + secretKey + ";"
+}
+
+// queryInt returns the integer query parameter key, or def when it is missing or malformed.
+func queryInt(r *http.Request, key string, def int) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,293 @@
+package tenabletest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+)
+
+type ioAgentGroup struct {
+	group   go_tenable.AgentGroup
+	members map[int]bool
+}
+
+// ioAgentTask is a bulk agent operation. The operation is applied when it is requested; the task reports NEW once and
+// COMPLETED from the first status poll on.
+type ioAgentTask struct {
+	groupID int
+	task    go_tenable.AgentBulkTask
+}
+
+// serveScanner handles the scanners/{id}/... routes. Any numeric scanner ID is accepted and all of them share the
+// same agents and groups.
+func (s *IOServer) serveScanner(w http.ResponseWriter, r *http.Request, segments []string) {
+	if _, err := strconv.Atoi(segments[0]); err != nil {
+		ioError(w, http.StatusNotFound, "Scanner not found")
+		return
+	}
+	route := strings.Join(segments[1:], "/")
+	switch {
+	case route == "agents" && r.Method == "GET":
+		s.listAgents(w, r)
+	case route == "agents/_bulk/unlink" && r.Method == "POST":
+		s.bulkUnlinkAgents(w, r)
+	case len(segments) == 4 && segments[1] == "agents" && segments[2] == "_bulk" && r.Method == "GET":
+		s.agentTaskStatus(w, 0, segments[3])
+	case len(segments) == 3 && segments[1] == "agents" && r.Method == "GET":
+		s.getAgent(w, segments[2])
+	case len(segments) == 3 && segments[1] == "agents" && r.Method == "DELETE":
+		s.unlinkAgent(w, segments[2])
+	case route == "agent-groups" && r.Method == "GET":
+		s.listAgentGroups(w)
+	case route == "agent-groups" && r.Method == "POST":
+		s.createAgentGroup(w, r)
+	case len(segments) == 3 && segments[1] == "agent-groups" && r.Method == "DELETE":
+		s.deleteAgentGroup(w, segments[2])
+	case len(segments) == 5 && segments[1] == "agent-groups" && segments[3] == "agents" &&
+		(r.Method == "PUT" || r.Method == "DELETE"):
+		s.updateAgentGroup(w, segments[2], segments[4], r.Method == "PUT")
+	case len(segments) == 6 && segments[1] == "agent-groups" && segments[3] == "agents" && segments[4] == "_bulk" &&
+		r.Method == "POST":
+		s.bulkUpdateAgentGroup(w, r, segments[2], segments[5])
+	case len(segments) == 6 && segments[1] == "agent-groups" && segments[3] == "agents" && segments[4] == "_bulk" &&
+		r.Method == "GET":
+		groupID, _ := strconv.Atoi(segments[2])
+		s.agentTaskStatus(w, groupID, segments[5])
+	default:
+		ioError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *IOServer) listAgents(w http.ResponseWriter, r *http.Request) {
+	offset := queryInt(r, "offset", 0)
+	limit := queryInt(r, "limit", 50)
+	if offset > len(s.agents) {
+		offset = len(s.agents)
+	}
+	end := offset + limit
+	if end > len(s.agents) {
+		end = len(s.agents)
+	}
+
+	var resp go_tenable.AgentResponse
+	for _, agent := range s.agents[offset:end] {
+		resp.Agents = append(resp.Agents, s.agentWithGroups(agent))
+	}
+	resp.Pagination.Total = len(s.agents)
+	resp.Pagination.Limit = limit
+	resp.Pagination.Offset = offset
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *IOServer) getAgent(w http.ResponseWriter, agentID string) {
+	i := s.agentIndex(agentID)
+	if i < 0 {
+		ioError(w, http.StatusNotFound, "Agent not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.agentWithGroups(s.agents[i]))
+}
+
+func (s *IOServer) unlinkAgent(w http.ResponseWriter, agentID string) {
+	i := s.agentIndex(agentID)
+	if i < 0 {
+		ioError(w, http.StatusNotFound, "Agent not found")
+		return
+	}
+	s.unlink(s.agents[i].ID)
+	w.WriteHeader(http.StatusOK)
+}
+
+// bulkUnlinkAgents unlinks the agents in items. Unknown agent IDs are ignored.
+func (s *IOServer) bulkUnlinkAgents(w http.ResponseWriter, r *http.Request) {
+	items, ok := bulkItems(w, r)
+	if !ok {
+		return
+	}
+	for _, id := range items {
+		s.unlink(id)
+	}
+	s.startAgentTask(w, 0, len(items))
+}
+
+func (s *IOServer) unlink(agentID int) {
+	for i, agent := range s.agents {
+		if agent.ID == agentID {
+			s.agents = append(s.agents[:i:i], s.agents[i+1:]...)
+			break
+		}
+	}
+	for _, group := range s.agentGroups {
+		delete(group.members, agentID)
+	}
+}
+
+func (s *IOServer) listAgentGroups(w http.ResponseWriter) {
+	resp := go_tenable.AgentGroupListResponse{Groups: []go_tenable.AgentGroup{}}
+	for _, group := range s.agentGroups {
+		resp.Groups = append(resp.Groups, group.view())
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *IOServer) createAgentGroup(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		ioError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	s.nextAgentGroup++
+	now := int(time.Now().Unix())
+	group := &ioAgentGroup{
+		group: go_tenable.AgentGroup{
+			ID:               s.nextAgentGroup,
+			UUID:             fmt.Sprintf("00000000-0000-4000-9000-%012d", s.nextAgentGroup),
+			Name:             req.Name,
+			Owner:            "tenabletest",
+			CreationDate:     now,
+			LastModifiedDate: now,
+			Timestamp:        now,
+		},
+		members: make(map[int]bool),
+	}
+	s.agentGroups = append(s.agentGroups, group)
+	writeJSON(w, http.StatusOK, group.view())
+}
+
+func (s *IOServer) deleteAgentGroup(w http.ResponseWriter, groupID string) {
+	for i, group := range s.agentGroups {
+		if strconv.Itoa(group.group.ID) == groupID {
+			s.agentGroups = append(s.agentGroups[:i:i], s.agentGroups[i+1:]...)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	ioError(w, http.StatusNotFound, "Agent group not found")
+}
+
+func (s *IOServer) updateAgentGroup(w http.ResponseWriter, groupID string, agentID string, add bool) {
+	group := s.agentGroup(groupID)
+	i := s.agentIndex(agentID)
+	if group == nil || i < 0 {
+		ioError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if add {
+		group.members[s.agents[i].ID] = true
+	} else {
+		delete(group.members, s.agents[i].ID)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// bulkUpdateAgentGroup adds or removes the agents in items. Unknown agent IDs are ignored.
+func (s *IOServer) bulkUpdateAgentGroup(w http.ResponseWriter, r *http.Request, groupID string, action string) {
+	group := s.agentGroup(groupID)
+	if group == nil || (action != "add" && action != "remove") {
+		ioError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	items, ok := bulkItems(w, r)
+	if !ok {
+		return
+	}
+	for _, id := range items {
+		if action == "remove" {
+			delete(group.members, id)
+		} else if s.agentIndex(strconv.Itoa(id)) >= 0 {
+			group.members[id] = true
+		}
+	}
+	s.startAgentTask(w, group.group.ID, len(items))
+}
+
+func (s *IOServer) startAgentTask(w http.ResponseWriter, groupID int, units int) {
+	s.nextAgentTask++
+	now := go_tenable.UnixMillis(time.Now().UnixNano() / int64(time.Millisecond))
+	task := &ioAgentTask{
+		groupID: groupID,
+		task: go_tenable.AgentBulkTask{
+			TaskID:         fmt.Sprintf("00000000-0000-4000-a000-%012d", s.nextAgentTask),
+			ContainerUUID:  "tenabletest",
+			Status:         go_tenable.AgentTaskNew,
+			StartTime:      now,
+			LastUpdateTime: now,
+			TotalWorkUnits: units,
+		},
+	}
+	s.agentTasks[task.task.TaskID] = task
+	writeJSON(w, http.StatusOK, task.task)
+}
+
+func (s *IOServer) agentTaskStatus(w http.ResponseWriter, groupID int, taskID string) {
+	task, ok := s.agentTasks[taskID]
+	if !ok || task.groupID != groupID {
+		ioError(w, http.StatusNotFound, "Task not found")
+		return
+	}
+	if task.task.Status != go_tenable.AgentTaskCompleted {
+		now := go_tenable.UnixMillis(time.Now().UnixNano() / int64(time.Millisecond))
+		task.task.Status = go_tenable.AgentTaskCompleted
+		task.task.EndTime = now
+		task.task.LastUpdateTime = now
+		task.task.TotalWorkUnitsCompleted = task.task.TotalWorkUnits
+		task.task.CompletionPercentage = 100
+	}
+	writeJSON(w, http.StatusOK, task.task)
+}
+
+func bulkItems(w http.ResponseWriter, r *http.Request) ([]int, bool) {
+	var req struct {
+		Items []int `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Items) == 0 {
+		ioError(w, http.StatusBadRequest, "items is required")
+		return nil, false
+	}
+	return req.Items, true
+}
+
+func (s *IOServer) agentIndex(agentID string) int {
+	for i, agent := range s.agents {
+		if strconv.Itoa(agent.ID) == agentID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *IOServer) agentGroup(groupID string) *ioAgentGroup {
+	for _, group := range s.agentGroups {
+		if strconv.Itoa(group.group.ID) == groupID {
+			return group
+		}
+	}
+	return nil
+}
+
+// agentWithGroups returns a copy of agent listing the groups it currently belongs to.
+func (s *IOServer) agentWithGroups(agent go_tenable.Agent) go_tenable.Agent {
+	agent.Groups = agent.Groups[:0:0]
+	for _, group := range s.agentGroups {
+		if group.members[agent.ID] {
+			agent.Groups = append(agent.Groups, struct {
+				Name string `json:"name"`
+				ID   int    `json:"id"`
+			}{Name: group.group.Name, ID: group.group.ID})
+		}
+	}
+	return agent
+}
+
+func (group *ioAgentGroup) view() go_tenable.AgentGroup {
+	view := group.group
+	view.AgentsCount = len(group.members)
+	return view
+}
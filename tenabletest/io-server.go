@@ -0,0 +1,355 @@
+package tenabletest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+)
+
+// IOServer fakes the Tenable.io endpoints go-tenable calls: assets/export, vulns/export, compliance/export,
+// scanners/{id}/agents, scanners/{id}/agent-groups, audit-log/v1/events and scans. Requests must carry the fixture API
+// keys in the X-ApiKeys header.
+//
+// Exports behave like the real service: each status poll makes one more chunk available until the export is
+// FINISHED or cancelled. Agents can be unlinked and grouped; bulk agent operations take effect immediately and report
+// COMPLETED from their first status poll.
+type IOServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	fixtures   Fixtures
+	exports    map[string]*ioExport
+	nextExport int
+
+	agents         []go_tenable.Agent
+	agentGroups    []*ioAgentGroup
+	agentTasks     map[string]*ioAgentTask
+	nextAgentGroup int
+	nextAgentTask  int
+}
+
+type ioExport struct {
+	exportType string
+	filters    json.RawMessage
+	perChunk   int
+	created    time.Time
+	chunks     []json.RawMessage
+	available  int
+	cancelled  bool
+}
+
+// NewIOServer starts a TLS server seeded from f. Close it when the test is done.
+func NewIOServer(f Fixtures) *IOServer {
+	s := &IOServer{
+		fixtures: f,
+		exports:  make(map[string]*ioExport),
+
+		agents:     append([]go_tenable.Agent(nil), f.IOAgents...),
+		agentTasks: make(map[string]*ioAgentTask),
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Options returns the client options that point a go-tenable client at the server.
+func (s *IOServer) Options() []go_tenable.Option {
+	return []go_tenable.Option{
+		go_tenable.WithBaseURL(s.URL),
+		go_tenable.WithHTTPClient(s.Client()),
+	}
+}
+
+func (s *IOServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("X-ApiKeys") != apiKeysHeader(s.fixtures.IOAccessKey, s.fixtures.IOSecretKey) {
+		ioError(w, http.StatusUnauthorized, "Invalid Credentials")
+		return
+	}
+
+	segments := pathSegments(r, "")
+	route := strings.Join(segments, "/")
+	switch {
+	case route == "assets/export" && r.Method == "POST":
+		s.requestAssetExport(w, r)
+	case route == "vulns/export" && r.Method == "POST":
+		s.requestVulnExport(w, r)
+	case route == "compliance/export" && r.Method == "POST":
+		s.requestComplianceExport(w, r)
+	case len(segments) == 3 && segments[1] == "export" && segments[2] == "status" && r.Method == "GET":
+		s.listExports(w, segments[0])
+	case len(segments) == 4 && segments[1] == "export" && segments[3] == "cancel" && r.Method == "POST":
+		s.cancelExport(w, segments[0], segments[2])
+	case len(segments) == 4 && segments[1] == "export" && segments[3] == "status" && r.Method == "GET":
+		s.exportStatus(w, segments[0], segments[2])
+	case len(segments) == 5 && segments[1] == "export" && segments[3] == "chunks" && r.Method == "GET":
+		s.downloadChunk(w, segments[0], segments[2], segments[4])
+	case len(segments) >= 3 && segments[0] == "scanners":
+		s.serveScanner(w, r, segments[1:])
+	case route == "audit-log/v1/events" && r.Method == "GET":
+		s.listEvents(w, r)
+	case route == "scans" && r.Method == "GET":
+		writeJSON(w, http.StatusOK, go_tenable.ScanListResponse{
+			Scans:     s.fixtures.IOScans,
+			Timestamp: int(time.Now().Unix()),
+		})
+	default:
+		ioError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *IOServer) requestAssetExport(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ChunkSize int             `json:"chunk_size"`
+		Filters   json.RawMessage `json:"filters"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ioError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	if req.ChunkSize < go_tenable.MinAssetChunkSize || req.ChunkSize > go_tenable.MaxAssetChunkSize {
+		ioError(w, http.StatusBadRequest, "chunk_size must be between 100 and 10000")
+		return
+	}
+
+	s.startExport(w, &ioExport{exportType: "assets", filters: req.Filters, perChunk: req.ChunkSize,
+		chunks: chunkRecords(s.fixtures.IOAssets, req.ChunkSize)})
+}
+
+// requestComplianceExport cuts the fixture findings into chunks of num_findings. Filters are ignored.
+func (s *IOServer) requestComplianceExport(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		NumFindings *int            `json:"num_findings"`
+		Filters     json.RawMessage `json:"filters"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ioError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	numFindings := 5000
+	if req.NumFindings != nil {
+		numFindings = *req.NumFindings
+	}
+	if numFindings < 50 || numFindings > 10000 {
+		ioError(w, http.StatusBadRequest, "num_findings must be between 50 and 10000")
+		return
+	}
+	s.startExport(w, &ioExport{exportType: "compliance", filters: req.Filters, perChunk: numFindings,
+		chunks: chunkRecords(s.fixtures.IOCompliance, numFindings)})
+}
+
+// chunkRecords cuts a fixture slice into chunks of size records. The fixture is round tripped through JSON so it can
+// be cut without depending on the element type.
+func chunkRecords(fixture interface{}, size int) []json.RawMessage {
+	var records []json.RawMessage
+	data, _ := json.Marshal(fixture)
+	_ = json.Unmarshal(data, &records)
+
+	var chunks []json.RawMessage
+	for start := 0; start < len(records); start += size {
+		end := start + size
+		if end > len(records) {
+			end = len(records)
+		}
+		chunk, _ := json.Marshal(records[start:end])
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// requestVulnExport cuts the fixture findings into chunks covering num_assets assets each. Filters are ignored.
+func (s *IOServer) requestVulnExport(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		NumAssets *int            `json:"num_assets"`
+		Filters   json.RawMessage `json:"filters"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ioError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	numAssets := 50
+	if req.NumAssets != nil {
+		numAssets = *req.NumAssets
+	}
+	if numAssets < go_tenable.MinVulnExportAssets || numAssets > go_tenable.MaxVulnExportAssets {
+		ioError(w, http.StatusBadRequest, "num_assets must be between 50 and 5000")
+		return
+	}
+
+	export := &ioExport{exportType: "vulns", filters: req.Filters, perChunk: numAssets}
+	var chunk []go_tenable.VulnerabilityFinding
+	seen := make(map[string]bool)
+	for _, finding := range s.fixtures.IOVulns {
+		if !seen[finding.Asset.UUID] && len(seen) == numAssets {
+			data, _ := json.Marshal(chunk)
+			export.chunks = append(export.chunks, data)
+			chunk = nil
+			seen = make(map[string]bool)
+		}
+		seen[finding.Asset.UUID] = true
+		chunk = append(chunk, finding)
+	}
+	if len(chunk) > 0 {
+		data, _ := json.Marshal(chunk)
+		export.chunks = append(export.chunks, data)
+	}
+	s.startExport(w, export)
+}
+
+func (s *IOServer) startExport(w http.ResponseWriter, export *ioExport) {
+	s.nextExport++
+	uuid := fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextExport)
+	export.created = time.Now()
+	s.exports[uuid] = export
+	writeJSON(w, http.StatusOK, go_tenable.ExportRequestResponse{ExportUUID: uuid})
+}
+
+func (s *IOServer) exportStatus(w http.ResponseWriter, exportType string, uuid string) {
+	export, ok := s.exports[uuid]
+	if !ok || export.exportType != exportType {
+		ioError(w, http.StatusNotFound, "Export not found")
+		return
+	}
+	if export.available < len(export.chunks) && !export.cancelled {
+		export.available++
+	}
+	writeJSON(w, http.StatusOK, export.status(uuid))
+}
+
+func (s *IOServer) listExports(w http.ResponseWriter, exportType string) {
+	list := go_tenable.ExportListResponse{Exports: []go_tenable.ExportStatusResponse{}}
+	for uuid, export := range s.exports {
+		if export.exportType == exportType {
+			list.Exports = append(list.Exports, export.status(uuid))
+		}
+	}
+	sort.Slice(list.Exports, func(i, j int) bool { return list.Exports[i].UUID < list.Exports[j].UUID })
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *IOServer) cancelExport(w http.ResponseWriter, exportType string, uuid string) {
+	export, ok := s.exports[uuid]
+	if !ok || export.exportType != exportType {
+		ioError(w, http.StatusNotFound, "Export not found")
+		return
+	}
+	if export.available < len(export.chunks) {
+		export.cancelled = true
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": export.status(uuid).Status})
+}
+
+func (export *ioExport) status(uuid string) go_tenable.ExportStatusResponse {
+	status := go_tenable.ExportStatusResponse{
+		UUID:              uuid,
+		Status:            go_tenable.ExportStatusProcessing,
+		ChunksAvailable:   []int{},
+		TotalChunks:       len(export.chunks),
+		NumAssetsPerChunk: export.perChunk,
+		Filters:           export.filters,
+		Created:           go_tenable.UnixMillis(export.created.UnixNano() / int64(time.Millisecond)),
+	}
+	for id := 1; id <= export.available; id++ {
+		status.ChunksAvailable = append(status.ChunksAvailable, id)
+	}
+	status.ChunksAvailableCount = export.available
+	status.FinishedChunks = export.available
+	switch {
+	case export.cancelled:
+		status.Status = go_tenable.ExportStatusCancelled
+		for id := export.available + 1; id <= len(export.chunks); id++ {
+			status.ChunksCancelled = append(status.ChunksCancelled, id)
+		}
+	case export.available == len(export.chunks):
+		status.Status = go_tenable.ExportStatusFinished
+	}
+	return status
+}
+
+func (s *IOServer) downloadChunk(w http.ResponseWriter, exportType string, uuid string, chunkID string) {
+	export, ok := s.exports[uuid]
+	if !ok || export.exportType != exportType {
+		ioError(w, http.StatusNotFound, "Export not found")
+		return
+	}
+	id, err := strconv.Atoi(chunkID)
+	if err != nil || id < 1 || id > export.available {
+		ioError(w, http.StatusNotFound, "Chunk not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(export.chunks[id-1])
+}
+
+// listEvents returns events newest first and supports the limit parameter and the filters the audit log documents:
+// date.gt, date.lt, actor_id.match and target_id.match. Requests with any other filter are rejected.
+func (s *IOServer) listEvents(w http.ResponseWriter, r *http.Request) {
+	limit := queryInt(r, "limit", 50)
+	var match []func(go_tenable.Event) bool
+	for _, f := range r.URL.Query()["f"] {
+		parts := strings.SplitN(f, ":", 2)
+		if len(parts) != 2 {
+			ioError(w, http.StatusBadRequest, "Invalid filter "+f)
+			return
+		}
+		value := parts[1]
+		switch parts[0] {
+		case "date.gt", "date.lt":
+			date, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				ioError(w, http.StatusBadRequest, "Invalid date "+value)
+				return
+			}
+			if parts[0] == "date.gt" {
+				match = append(match, func(event go_tenable.Event) bool { return event.Received.After(date) })
+			} else {
+				match = append(match, func(event go_tenable.Event) bool { return event.Received.Before(date) })
+			}
+		case "actor_id.match":
+			match = append(match, func(event go_tenable.Event) bool { return event.Actor.ID == value })
+		case "target_id.match":
+			match = append(match, func(event go_tenable.Event) bool { return event.Target.ID == value })
+		default:
+			ioError(w, http.StatusBadRequest, "Invalid filter "+f)
+			return
+		}
+	}
+
+	events := append([]go_tenable.Event(nil), s.fixtures.IOEvents...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Received.After(events[j].Received) })
+
+	var resp go_tenable.AuditLogResponse
+	resp.Events = []go_tenable.Event{}
+events:
+	for _, event := range events {
+		if len(resp.Events) == limit {
+			break
+		}
+		for _, m := range match {
+			if !m(event) {
+				continue events
+			}
+		}
+		resp.Events = append(resp.Events, event)
+	}
+	resp.Pagination.Total = len(events)
+	resp.Pagination.Limit = limit
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func ioError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"statusCode": status,
+		"error":      http.StatusText(status),
+		"message":    message,
+	})
+}
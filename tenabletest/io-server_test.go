@@ -0,0 +1,275 @@
+package tenabletest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+var runOptions = go_tenable.ExportRunOptions{PollInterval: time.Millisecond}
+
+func newIO(t *testing.T, srv *tenabletest.IOServer, f tenabletest.Fixtures) go_tenable.TenableIO {
+	t.Helper()
+	tio, err := go_tenable.NewTenableIO(f.IOAccessKey, f.IOSecretKey, srv.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tio
+}
+
+// runExport requests an export with payload and returns the number of records Run delivered per chunk. Run calls fn
+// from several workers at once.
+func runExport(t *testing.T, tio go_tenable.TenableIO, exportType string, payload []byte) map[int]int {
+	t.Helper()
+	export := tio.NewExport(exportType)
+	if _, err := export.RequestExport(payload); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	records := make(map[int]int)
+	err := export.Run(runOptions, func(record go_tenable.ExportRecord) error {
+		switch {
+		case exportType == go_tenable.ExportTypeAssets && record.Asset == nil,
+			exportType == go_tenable.ExportTypeVulns && record.Vuln == nil,
+			exportType == go_tenable.ExportTypeCompliance && record.Compliance == nil:
+			t.Errorf("chunk %v delivered a record without the %v field set", record.ChunkID, exportType)
+		}
+		mu.Lock()
+		records[record.ChunkID]++
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestIOServerAssetExport(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	f.IOAssets = make(go_tenable.AssetChunkDownloadResponse, 250)
+	for i := range f.IOAssets {
+		f.IOAssets[i].ID = "asset-" + strconv.Itoa(i)
+	}
+	srv := tenabletest.NewIOServer(f)
+	defer srv.Close()
+	tio := newIO(t, srv, f)
+
+	payload, err := go_tenable.AssetExportRequest{ChunkSize: 100}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := runExport(t, tio, go_tenable.ExportTypeAssets, payload)
+	if len(records) != 3 || records[1] != 100 || records[2] != 100 || records[3] != 50 {
+		t.Fatalf("got records per chunk %v, want 100, 100 and 50", records)
+	}
+}
+
+func TestIOServerVulnExport(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv := tenabletest.NewIOServer(f)
+	defer srv.Close()
+	tio := newIO(t, srv, f)
+
+	payload, err := go_tenable.VulnExportRequest{NumAssets: go_tenable.MinVulnExportAssets}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := runExport(t, tio, go_tenable.ExportTypeVulns, payload)
+	if len(records) != 1 || records[1] != len(f.IOVulns) {
+		t.Fatalf("got records per chunk %v, want %v in chunk 1", records, len(f.IOVulns))
+	}
+}
+
+func TestIOServerComplianceExport(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv := tenabletest.NewIOServer(f)
+	defer srv.Close()
+	tio := newIO(t, srv, f)
+
+	payload, err := json.Marshal(go_tenable.ComplianceExportRequest{NumFindings: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := runExport(t, tio, go_tenable.ExportTypeCompliance, payload)
+	if len(records) != 1 || records[1] != len(f.IOCompliance) {
+		t.Fatalf("got records per chunk %v, want %v in chunk 1", records, len(f.IOCompliance))
+	}
+}
+
+func TestIOServerListAndCancelExports(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv := tenabletest.NewIOServer(f)
+	defer srv.Close()
+	tio := newIO(t, srv, f)
+	payload, err := go_tenable.AssetExportRequest{ChunkSize: 100}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finished := tio.NewExport(go_tenable.ExportTypeAssets)
+	if _, err = finished.RequestExport(payload); err != nil {
+		t.Fatal(err)
+	}
+	if err = finished.Run(runOptions, func(go_tenable.ExportRecord) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	cancelled := tio.NewExport(go_tenable.ExportTypeAssets)
+	if _, err = cancelled.RequestExport(payload); err != nil {
+		t.Fatal(err)
+	}
+	status, err := cancelled.Cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != go_tenable.ExportStatusCancelled {
+		t.Errorf("Cancel returned status %q, want %v", status, go_tenable.ExportStatusCancelled)
+	}
+	err = cancelled.Run(runOptions, func(go_tenable.ExportRecord) error {
+		t.Error("Run delivered a record from a cancelled export")
+		return nil
+	})
+	if !errors.Is(err, go_tenable.ErrExportFailed) {
+		t.Fatalf("Run of a cancelled export: got %v, want ErrExportFailed", err)
+	}
+
+	exports, err := tio.ListExports(go_tenable.ExportTypeAssets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := make(map[string]string)
+	for _, export := range exports {
+		statuses[export.UUID] = export.Status
+	}
+	if len(statuses) != 2 || statuses[finished.ExportUUID] != go_tenable.ExportStatusFinished ||
+		statuses[cancelled.ExportUUID] != go_tenable.ExportStatusCancelled {
+		t.Fatalf("ListExports returned statuses %v", statuses)
+	}
+	if exports, err = tio.ListExports(go_tenable.ExportTypeVulns); err != nil || len(exports) != 0 {
+		t.Fatalf("ListExports(vulns) returned %v, %v; want no exports", exports, err)
+	}
+}
+
+func TestIOServerPaging(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv := tenabletest.NewIOServer(f)
+	defer srv.Close()
+	tio := newIO(t, srv, f)
+	ctx := context.Background()
+
+	var agents []int
+	err := tio.Agents(ctx, 2).ForEach(func(agent go_tenable.Agent) error {
+		agents = append(agents, agent.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != len(f.IOAgents) {
+		t.Errorf("Agents returned %v, want %v agents", agents, len(f.IOAgents))
+	}
+
+	var events []string
+	err = tio.Events(ctx, go_tenable.EventFilter{}, 2).ForEach(func(event go_tenable.Event) error {
+		events = append(events, event.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(f.IOEvents) {
+		t.Errorf("Events returned %v, want %v events", events, len(f.IOEvents))
+	}
+}
+
+func TestIOServerBulkAgentTasks(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv := tenabletest.NewIOServer(f)
+	defer srv.Close()
+	tio := newIO(t, srv, f)
+
+	group, err := tio.CreateAgentGroup("Linux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := tio.AddAgentsToGroup(group.ID, []int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task, err = tio.WaitForAgentTask(task, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != go_tenable.AgentTaskCompleted {
+		t.Errorf("AddAgentsToGroup task ended %v, want %v", task.Status, go_tenable.AgentTaskCompleted)
+	}
+	if count := agentsInGroup(t, tio, group.ID); count != 3 {
+		t.Errorf("group holds %v agents after AddAgentsToGroup, want 3", count)
+	}
+	agent, err := tio.GetAgent(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agent.Groups) != 1 || agent.Groups[0].ID != group.ID {
+		t.Errorf("agent 2 belongs to groups %+v, want only %v", agent.Groups, group.ID)
+	}
+
+	if task, err = tio.RemoveAgentsFromGroup(group.ID, []int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tio.WaitForAgentTask(task, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if count := agentsInGroup(t, tio, group.ID); count != 1 {
+		t.Errorf("group holds %v agents after RemoveAgentsFromGroup, want 1", count)
+	}
+
+	if task, err = tio.UnlinkAgents([]int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tio.WaitForAgentTask(task, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	pages, err := tio.ListAgents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var linked []int
+	for _, page := range pages {
+		for _, agent := range page.Agents {
+			linked = append(linked, agent.ID)
+		}
+	}
+	if len(linked) != 1 || linked[0] != 3 {
+		t.Errorf("agents %v are linked after UnlinkAgents, want only agent 3", linked)
+	}
+
+	if err = tio.DeleteAgentGroup(group.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = tio.DeleteAgentGroup(group.ID); err == nil {
+		t.Fatal("deleting a deleted group succeeded")
+	}
+}
+
+func agentsInGroup(t *testing.T, tio go_tenable.TenableIO, groupID int) int {
+	t.Helper()
+	groups, err := tio.ListAgentGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, group := range groups {
+		if group.ID == groupID {
+			return group.AgentsCount
+		}
+	}
+	t.Fatalf("group %v is not listed", groupID)
+	return 0
+}
@@ -0,0 +1,58 @@
+package tenabletest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/thathaneydude/go-tenable"
+)
+
+// NessusServer fakes the Nessus server/status, server/properties and settings/health/stats endpoints. Requests must
+// carry the fixture API keys in the X-ApiKeys header.
+type NessusServer struct {
+	*httptest.Server
+
+	fixtures Fixtures
+}
+
+// NewNessusServer starts a TLS server seeded from f. Close it when the test is done.
+func NewNessusServer(f Fixtures) *NessusServer {
+	s := &NessusServer{fixtures: f}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Options returns the client options that point a go-tenable client at the server.
+func (s *NessusServer) Options() []go_tenable.Option {
+	return []go_tenable.Option{
+		go_tenable.WithBaseURL(s.URL),
+		go_tenable.WithHTTPClient(s.Client()),
+	}
+}
+
+func (s *NessusServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-ApiKeys") != apiKeysHeader(s.fixtures.NessusAccessKey, s.fixtures.NessusSecretKey) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "Invalid Credentials"})
+		return
+	}
+	if r.Method != "GET" {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+		return
+	}
+
+	switch strings.Join(pathSegments(r, ""), "/") {
+	case "server/status":
+		writeJSON(w, http.StatusOK, s.fixtures.NessusStatus)
+	case "server/properties":
+		writeJSON(w, http.StatusOK, s.fixtures.NessusProperties)
+	case "settings/health/stats":
+		health := s.fixtures.NessusHealth
+		if count := queryInt(r, "count", 1); count < len(health.PerfStatsHistory) {
+			health.PerfStatsHistory = health.PerfStatsHistory[:count]
+		}
+		writeJSON(w, http.StatusOK, health)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "The requested file was not found"})
+	}
+}
@@ -0,0 +1,43 @@
+package tenabletest_test
+
+import (
+	"testing"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+func TestNessusServer(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv := tenabletest.NewNessusServer(f)
+	defer srv.Close()
+	nessus, err := go_tenable.NewNessus(f.NessusAccessKey, f.NessusSecretKey, "", 0, srv.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := nessus.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != "ready" {
+		t.Errorf("got status %q, want ready", status.Status)
+	}
+
+	health, err := nessus.GetHealthStats(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health.PerfStatsCurrent.NessusDataDiskFree != f.NessusHealth.PerfStatsCurrent.NessusDataDiskFree {
+		t.Errorf("got free disk %v, want %v", health.PerfStatsCurrent.NessusDataDiskFree,
+			f.NessusHealth.PerfStatsCurrent.NessusDataDiskFree)
+	}
+
+	bad, err := go_tenable.NewNessus(f.NessusAccessKey, "wrong", "", 0, srv.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bad.GetStatus(); !go_tenable.IsUnauthorized(err) {
+		t.Fatalf("GetStatus with a wrong key: got %v, want an unauthorized APIError", err)
+	}
+}
@@ -0,0 +1,312 @@
+package tenabletest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thathaneydude/go-tenable"
+)
+
+// SCServer fakes the Tenable.sc REST API below /rest: token, asset, repository, acceptRiskRule, recastRiskRule and
+// analysis. Requests must carry a session from the token endpoint or the fixture API keys.
+type SCServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	fixtures    Fixtures
+	assets      []go_tenable.AssetResponse
+	definedIPs  map[string]string
+	sessions    map[string]string
+	tokenStatus int
+	nextToken   int
+	nextAssetID int
+}
+
+// NewSCServer starts a TLS server seeded from f. Close it when the test is done.
+func NewSCServer(f Fixtures) *SCServer {
+	s := &SCServer{
+		fixtures:    f,
+		assets:      append([]go_tenable.AssetResponse(nil), f.SCAssets...),
+		definedIPs:  make(map[string]string),
+		sessions:    make(map[string]string),
+		tokenStatus: http.StatusForbidden,
+		nextToken:   1000,
+		nextAssetID: 1,
+	}
+	for _, asset := range s.assets {
+		if id, err := strconv.Atoi(asset.ID); err == nil && id >= s.nextAssetID {
+			s.nextAssetID = id + 1
+		}
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Options returns the client options that point a go-tenable client at the server.
+func (s *SCServer) Options() []go_tenable.Option {
+	return []go_tenable.Option{
+		go_tenable.WithBaseURL(s.URL + "/rest"),
+		go_tenable.WithHTTPClient(s.Client()),
+	}
+}
+
+// ExpireSessions invalidates every session token, as Tenable.sc does when a session times out.
+func (s *SCServer) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]string)
+}
+
+// SetInvalidTokenStatus sets the HTTP status of the error_code 74 response to a request with an unknown or expired
+// session. It is http.StatusForbidden by default; Tenable.sc sometimes sends the envelope with http.StatusOK.
+func (s *SCServer) SetInvalidTokenStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenStatus = status
+}
+
+// Assets returns the assets currently stored by the server.
+func (s *SCServer) Assets() []go_tenable.AssetResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]go_tenable.AssetResponse(nil), s.assets...)
+}
+
+func (s *SCServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := pathSegments(r, "/rest")
+	if len(segments) == 0 {
+		scError(w, http.StatusNotFound, 1, "Unknown endpoint")
+		return
+	}
+	if segments[0] == "token" {
+		s.serveToken(w, r)
+		return
+	}
+	if !s.authorized(r) {
+		scError(w, s.tokenStatus, 74, "Invalid token")
+		return
+	}
+
+	switch {
+	case segments[0] == "asset" && len(segments) == 1 && r.Method == "GET":
+		scWrite(w, map[string]interface{}{"usable": s.assets, "manageable": s.assets})
+	case segments[0] == "asset" && len(segments) == 1 && r.Method == "POST":
+		s.createAsset(w, r)
+	case segments[0] == "asset" && len(segments) == 2:
+		s.serveAsset(w, r, segments[1])
+	case segments[0] == "repository" && len(segments) == 1 && r.Method == "GET":
+		scWrite(w, s.fixtures.SCRepositories)
+	case segments[0] == "repository" && len(segments) == 2 && r.Method == "GET":
+		s.serveRepository(w, segments[1])
+	case segments[0] == "acceptRiskRule" && len(segments) == 1 && r.Method == "GET":
+		scWrite(w, s.fixtures.SCAcceptRiskRules)
+	case segments[0] == "recastRiskRule" && len(segments) == 1 && r.Method == "GET":
+		scWrite(w, s.fixtures.SCRecastRiskRules)
+	case segments[0] == "analysis" && len(segments) == 1 && r.Method == "POST":
+		s.serveAnalysis(w, r)
+	default:
+		scError(w, http.StatusNotFound, 1, "Unknown endpoint")
+	}
+}
+
+func (s *SCServer) authorized(r *http.Request) bool {
+	if key := r.Header.Get("X-APIKey"); key != "" {
+		return s.fixtures.SCAccessKey != "" &&
+			key == fmt.Sprintf("accesskey=%v; secretkey=%v;", s.fixtures.SCAccessKey, s.fixtures.SCSecretKey)
+	}
+	session, ok := s.sessions[r.Header.Get("X-SecurityCenter")]
+	if !ok {
+		return false
+	}
+	cookie, err := r.Cookie("TNS_SESSIONID")
+	return err == nil && cookie.Value == session
+}
+
+func (s *SCServer) serveToken(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		var req go_tenable.TokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			scError(w, http.StatusBadRequest, 1, "Invalid request body")
+			return
+		}
+		password, ok := s.fixtures.SCUsers[req.Username]
+		if !ok || password != req.Password {
+			scError(w, http.StatusForbidden, 1, "Invalid login credentials.")
+			return
+		}
+		s.nextToken++
+		token := strconv.Itoa(s.nextToken)
+		session := "session-" + token
+		s.sessions[token] = session
+		http.SetCookie(w, &http.Cookie{Name: "TNS_SESSIONID", Value: session, Path: "/", HttpOnly: true, Secure: true})
+		scWrite(w, map[string]interface{}{"token": s.nextToken, "unassociatedCert": "false"})
+	case "DELETE":
+		delete(s.sessions, r.Header.Get("X-SecurityCenter"))
+		scWrite(w, []interface{}{})
+	default:
+		scError(w, http.StatusMethodNotAllowed, 1, "Method not allowed")
+	}
+}
+
+func (s *SCServer) createAsset(w http.ResponseWriter, r *http.Request) {
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		scError(w, http.StatusBadRequest, 1, "Invalid request body")
+		return
+	}
+	if req["name"] == "" {
+		scError(w, http.StatusForbidden, 143, "Asset name is required.")
+		return
+	}
+
+	var asset go_tenable.AssetResponse
+	asset.ID = strconv.Itoa(s.nextAssetID)
+	s.nextAssetID++
+	asset.Name = req["name"]
+	asset.Type = req["type"]
+	asset.Description = req["description"]
+	asset.Tags = req["tags"]
+	asset.Status = "0"
+	asset.CreatedTime = strconv.FormatInt(time.Now().Unix(), 10)
+	asset.ModifiedTime = asset.CreatedTime
+	s.assets = append(s.assets, asset)
+	s.definedIPs[asset.ID] = req["definedIPs"]
+	scWrite(w, s.assetDetail(asset))
+}
+
+func (s *SCServer) serveAsset(w http.ResponseWriter, r *http.Request, id string) {
+	index := -1
+	for i, asset := range s.assets {
+		if asset.ID == id {
+			index = i
+		}
+	}
+	if index < 0 {
+		scError(w, http.StatusForbidden, 147, fmt.Sprintf("Asset #%v not found", id))
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		scWrite(w, s.assetDetail(s.assets[index]))
+	case "PATCH":
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			scError(w, http.StatusBadRequest, 1, "Invalid request body")
+			return
+		}
+		asset := &s.assets[index]
+		if v, ok := req["name"]; ok {
+			asset.Name = v
+		}
+		if v, ok := req["description"]; ok {
+			asset.Description = v
+		}
+		if v, ok := req["tags"]; ok {
+			asset.Tags = v
+		}
+		if v, ok := req["definedIPs"]; ok {
+			s.definedIPs[id] = v
+		}
+		asset.ModifiedTime = strconv.FormatInt(time.Now().Unix(), 10)
+		scWrite(w, s.assetDetail(*asset))
+	case "DELETE":
+		s.assets = append(s.assets[:index], s.assets[index+1:]...)
+		delete(s.definedIPs, id)
+		scWrite(w, []interface{}{})
+	default:
+		scError(w, http.StatusMethodNotAllowed, 1, "Method not allowed")
+	}
+}
+
+// assetDetail renders an asset the way the asset/{id} endpoint does, including its IP definition and count.
+func (s *SCServer) assetDetail(asset go_tenable.AssetResponse) map[string]interface{} {
+	definedIPs := s.definedIPs[asset.ID]
+	ipCount := 0
+	if definedIPs != "" {
+		ipCount = len(strings.Split(definedIPs, ","))
+	}
+	return map[string]interface{}{
+		"id":           asset.ID,
+		"name":         asset.Name,
+		"type":         asset.Type,
+		"description":  asset.Description,
+		"tags":         asset.Tags,
+		"status":       asset.Status,
+		"createdTime":  asset.CreatedTime,
+		"modifiedTime": asset.ModifiedTime,
+		"ipCount":      ipCount,
+		"typeFields":   map[string]string{"definedIPs": definedIPs},
+	}
+}
+
+func (s *SCServer) serveRepository(w http.ResponseWriter, id string) {
+	for _, repo := range s.fixtures.SCRepositories {
+		if repo.Id == id {
+			scWrite(w, map[string]interface{}{"id": repo.Id, "name": repo.Name, "type": "Local", "dataFormat": "IPv4"})
+			return
+		}
+	}
+	scError(w, http.StatusForbidden, 1, fmt.Sprintf("Repository #%v not found", id))
+}
+
+func (s *SCServer) serveAnalysis(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query struct {
+			StartOffset int `json:"startOffset"`
+			EndOffset   int `json:"endOffset"`
+		} `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		scError(w, http.StatusBadRequest, 1, "Invalid request body")
+		return
+	}
+	records := s.fixtures.SCAnalysis
+	start, end := req.Query.StartOffset, req.Query.EndOffset
+	if start > len(records) {
+		start = len(records)
+	}
+	if end > len(records) || end < start {
+		end = len(records)
+	}
+	scWrite(w, map[string]interface{}{
+		"totalRecords":    strconv.Itoa(len(records)),
+		"returnedRecords": end - start,
+		"startOffset":     strconv.Itoa(start),
+		"endOffset":       strconv.Itoa(end),
+		"results":         records[start:end],
+	})
+}
+
+// scWrite wraps response in the envelope every Tenable.sc endpoint returns.
+func scWrite(w http.ResponseWriter, response interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"type":       "regular",
+		"response":   response,
+		"error_code": 0,
+		"error_msg":  "",
+		"warnings":   []interface{}{},
+		"timestamp":  time.Now().Unix(),
+	})
+}
+
+func scError(w http.ResponseWriter, status int, errorCode int, errorMsg string) {
+	writeJSON(w, status, map[string]interface{}{
+		"type":       "regular",
+		"response":   "",
+		"error_code": errorCode,
+		"error_msg":  errorMsg,
+		"warnings":   []interface{}{},
+		"timestamp":  time.Now().Unix(),
+	})
+}
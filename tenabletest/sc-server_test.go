@@ -0,0 +1,171 @@
+package tenabletest_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/thathaneydude/go-tenable"
+	"github.com/thathaneydude/go-tenable/tenabletest"
+)
+
+// loggedInSC returns a client logged in to srv with the fixture credentials.
+func loggedInSC(t *testing.T, srv *tenabletest.SCServer) go_tenable.TenableSC {
+	t.Helper()
+	sc, err := go_tenable.NewTenableSC("", srv.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sc.Login(tenabletest.SCUsername, tenabletest.SCPassword); err != nil {
+		t.Fatal(err)
+	}
+	return sc
+}
+
+func TestSCServerLogin(t *testing.T) {
+	srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+	defer srv.Close()
+	sc, err := go_tenable.NewTenableSC("", srv.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = sc.Login(tenabletest.SCUsername, "wrong"); err == nil {
+		t.Fatal("Login with a wrong password succeeded")
+	}
+	if _, err = sc.ListAssets(); !go_tenable.IsUnauthorized(err) {
+		t.Fatalf("ListAssets before Login: got %v, want an unauthorized APIError", err)
+	}
+	token, err := sc.Login(tenabletest.SCUsername, tenabletest.SCPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Response.Token == 0 {
+		t.Error("Login returned no token")
+	}
+	if err = sc.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sc.ListAssets(); !go_tenable.IsUnauthorized(err) {
+		t.Fatalf("ListAssets after Logout: got %v, want an unauthorized APIError", err)
+	}
+}
+
+func TestSCServerListAssets(t *testing.T) {
+	srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+	defer srv.Close()
+	sc := loggedInSC(t, srv)
+	assets, err := sc.ListAssets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(assets.Response.Usable); got != 2 {
+		t.Fatalf("got %v assets, want 2", got)
+	}
+	if assets.Response.Usable[0].Name != "Web Servers" {
+		t.Errorf("got first asset %q, want Web Servers", assets.Response.Usable[0].Name)
+	}
+}
+
+func TestSCServerStaticIPAssetLifecycle(t *testing.T) {
+	srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+	defer srv.Close()
+	sc := loggedInSC(t, srv)
+
+	asset := sc.NewStaticIPAsset("Lab", "10.0.0.1,10.0.0.2", "Lab hosts", "lab")
+	if _, err := asset.Create(sc); err != nil {
+		t.Fatal(err)
+	}
+	if asset.ID == 0 {
+		t.Fatal("Create did not set the asset ID")
+	}
+	if got := len(srv.Assets()); got != 3 {
+		t.Fatalf("server holds %v assets after Create, want 3", got)
+	}
+
+	asset.Description = "Lab and staging hosts"
+	asset.DefinedIPs = "10.0.0.1,10.0.0.2,10.0.0.3"
+	if err := asset.Edit(sc); err != nil {
+		t.Fatal(err)
+	}
+	viewed := go_tenable.StaticIPAsset{ID: asset.ID}
+	if err := viewed.View(sc); err != nil {
+		t.Fatal(err)
+	}
+	if viewed.Name != "Lab" || viewed.Description != "Lab and staging hosts" ||
+		viewed.DefinedIPs != "10.0.0.1,10.0.0.2,10.0.0.3" {
+		t.Errorf("View after Edit returned %+v", viewed)
+	}
+	calculating, err := asset.Calculating(sc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calculating {
+		t.Error("Calculating reported true for an asset with IPs")
+	}
+
+	if err = asset.Delete(sc); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(srv.Assets()); got != 2 {
+		t.Fatalf("server holds %v assets after Delete, want 2", got)
+	}
+	if err = viewed.View(sc); err == nil {
+		t.Fatal("View of a deleted asset succeeded")
+	}
+}
+
+func TestSCServerAnalysisPaging(t *testing.T) {
+	srv := tenabletest.NewSCServer(tenabletest.DefaultFixtures())
+	defer srv.Close()
+	sc := loggedInSC(t, srv)
+	query := go_tenable.AnalysisQuery{Type: "vuln", SourceType: "cumulative", Tool: "vulnipdetail"}
+
+	var plugins []string
+	err := sc.Analysis(context.Background(), query, 2).ForEach(func(record json.RawMessage) error {
+		var result struct {
+			PluginID string `json:"pluginID"`
+		}
+		if err := json.Unmarshal(record, &result); err != nil {
+			return err
+		}
+		plugins = append(plugins, result.PluginID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plugins) != 3 || plugins[0] != "19506" || plugins[2] != "19508" {
+		t.Fatalf("got plugins %v, want 19506 to 19508", plugins)
+	}
+}
+
+func TestSCServerLists(t *testing.T) {
+	f := tenabletest.DefaultFixtures()
+	srv := tenabletest.NewSCServer(f)
+	defer srv.Close()
+	sc := loggedInSC(t, srv)
+
+	repos, err := sc.ListRepositories()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos.Repos) != len(f.SCRepositories) || repos.Repos[1].Name != f.SCRepositories[1].Name {
+		t.Errorf("ListRepositories returned %+v, want %+v", repos.Repos, f.SCRepositories)
+	}
+	accept, err := sc.ListRiskAcceptanceRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accept.AcceptRiskRules) != len(f.SCAcceptRiskRules) ||
+		accept.AcceptRiskRules[0].Plugin.ID != f.SCAcceptRiskRules[0].Plugin.ID {
+		t.Errorf("ListRiskAcceptanceRules returned %+v, want %+v", accept.AcceptRiskRules, f.SCAcceptRiskRules)
+	}
+	recast, err := sc.ListRiskRecastRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recast.RecastRules) != len(f.SCRecastRiskRules) {
+		t.Errorf("ListRiskRecastRules returned %+v, want %+v", recast.RecastRules, f.SCRecastRiskRules)
+	}
+}
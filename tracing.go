@@ -0,0 +1,123 @@
+package go_tenable
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// TracerProvider, Tracer and Span are the subset of a tracing API go-tenable needs. They are small enough to adapt
+// OpenTelemetry or any other tracer without this library depending on it:
+//
+//	type otelTracer struct{ t trace.Tracer }
+//
+//	func (o otelTracer) Start(ctx context.Context, name string) (context.Context, go_tenable.Span) {
+//		ctx, span := o.t.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+// Start must return a context carrying the new span so spans started from it become its children.
+type TracerProvider interface {
+	Tracer(name string) Tracer
+}
+
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a key and a string, int or bool value attached to a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attribute keys set on spans.
+const (
+	AttrProduct        = "tenable.product"
+	AttrEndpoint       = "tenable.endpoint"
+	AttrExportUUID     = "tenable.export_uuid"
+	AttrExportType     = "tenable.export_type"
+	AttrChunkID        = "tenable.chunk_id"
+	AttrAssetID        = "tenable.asset_id"
+	AttrAgentID        = "tenable.agent_id"
+	AttrAgentGroupID   = "tenable.agent_group_id"
+	AttrAttempt        = "tenable.attempt"
+	AttrHTTPMethod     = "http.method"
+	AttrHTTPStatusCode = "http.status_code"
+)
+
+// tracerName is the instrumentation name passed to TracerProvider.Tracer.
+const tracerName = "github.com/thathaneydude/go-tenable"
+
+// WithTracerProvider creates a span for every public API call, named after the method (e.g. "Export.DownloadChunk"),
+// with a child span for each HTTP attempt, retries included.
+func WithTracerProvider(provider TracerProvider) Option {
+	return func(o *clientOptions) {
+		o.tracerProvider = provider
+	}
+}
+
+// startSpan starts a span for a public method. endpoint is the endpoint template the method calls.
+func (bc baseClient) startSpan(ctx context.Context, name string, endpoint string, attrs ...Attribute) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if bc.tracer == nil {
+		return ctx, nopSpan{}
+	}
+	ctx, span := bc.tracer.Start(ctx, name)
+	span.SetAttributes(append([]Attribute{
+		{Key: AttrProduct, Value: string(bc.product)},
+		{Key: AttrEndpoint, Value: endpoint},
+	}, attrs...)...)
+	return ctx, span
+}
+
+// endSpan records err, if any, and ends the span.
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// finishAttemptSpan ends the span for an HTTP attempt. When a response was received the span stays open until its
+// body is closed, so it covers reading the body.
+func finishAttemptSpan(span Span, resp *http.Response, err error) {
+	if _, ok := span.(nopSpan); ok {
+		return
+	}
+	if err != nil {
+		endSpan(span, err)
+		return
+	}
+	span.SetAttributes(Attribute{Key: AttrHTTPStatusCode, Value: resp.StatusCode})
+	resp.Body = &spanBody{ReadCloser: resp.Body, span: span}
+}
+
+type spanBody struct {
+	io.ReadCloser
+	span Span
+	once sync.Once
+}
+
+func (b *spanBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.span.End)
+	return err
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...Attribute) {}
+
+func (nopSpan) RecordError(error) {}
+
+func (nopSpan) End() {}